@@ -0,0 +1,55 @@
+// Command sweep deletes InfluxDB resources leaked by interrupted acceptance test
+// runs. It is configured through the same environment variables as the provider.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/sweep"
+)
+
+func main() {
+	prefix := flag.String("prefix", "tf-acc-", "name prefix of the resources to delete")
+	flag.Parse()
+
+	serverURL := os.Getenv("INFLUXDB_URL")
+	token := os.Getenv("INFLUXDB_TOKEN")
+	org := os.Getenv("INFLUXDB_ORG")
+	if serverURL == "" || token == "" || org == "" {
+		log.Fatal("INFLUXDB_URL, INFLUXDB_TOKEN and INFLUXDB_ORG must be set")
+	}
+
+	client := influxdb2.NewClient(serverURL, token)
+	defer client.Close()
+
+	ctx := context.Background()
+	orgID, err := common.NewOrgCache(client).Resolve(ctx, org)
+	if err != nil {
+		log.Fatalf("unable to find organization %q: %s", org, err)
+	}
+
+	results, err := sweep.Sweep(ctx, apiclient.New(client), orgID, *prefix)
+	failed := false
+	for _, result := range results {
+		if result.Err != nil {
+			failed = true
+			fmt.Printf("failed to delete %s %s (%s): %s\n", result.Collection, result.Name, result.ID, result.Err)
+			continue
+		}
+		fmt.Printf("deleted %s %s (%s)\n", result.Collection, result.Name, result.ID)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
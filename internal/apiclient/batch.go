@@ -0,0 +1,106 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// listingTimeout bounds shared listings, which do not end with the context of
+// the read that started them
+const listingTimeout = 2 * time.Minute
+
+// Batch serves reads of single objects from one listing of their collection per
+// organization, so refreshing hundreds of checks or tasks costs a few paged
+// requests instead of one request per object. Each listed object is served once,
+// later reads of it and objects missing from the listing are fetched individually.
+type Batch struct {
+	api      *Client
+	disabled bool
+	listings singleflight.Group
+	mu       sync.Mutex
+	items    map[string]map[string]json.RawMessage
+}
+
+// NewBatch returns a batch reading through api. A disabled batch fetches every
+// object individually.
+func NewBatch(api *Client, disabled bool) *Batch {
+	return &Batch{
+		api:      api,
+		disabled: disabled,
+		items:    map[string]map[string]json.RawMessage{},
+	}
+}
+
+// Get returns the object with the given ID from a collection, e.g. "checks". The
+// organization selects the listing, objects of unknown organizations are fetched
+// individually.
+func (b *Batch) Get(ctx context.Context, collection, orgID, id string) ([]byte, error) {
+	if b.disabled || orgID == "" {
+		return b.api.Do(ctx, http.MethodGet, collection+"/"+id, nil)
+	}
+
+	// The shared listing must not fail because the read that started it timed
+	// out, so it runs without the caller's cancellation and with its own timeout,
+	// while each caller stops waiting when its own context ends
+	key := collection + "/" + orgID
+	listing := b.listings.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), listingTimeout)
+		defer cancel()
+
+		b.mu.Lock()
+		_, listed := b.items[key]
+		b.mu.Unlock()
+		if listed {
+			return nil, nil
+		}
+
+		// Tokens may be allowed to read single objects but not to list them, an
+		// empty listing makes every read fall back to fetching the object
+		items, err := b.api.ListAll(ctx, collection, url.Values{"orgID": {orgID}})
+		if err != nil {
+			tflog.Debug(ctx, "Listing failed, reading objects individually", map[string]interface{}{
+				"collection": collection,
+				"org_id":     orgID,
+				"error":      err.Error(),
+			})
+		}
+
+		byID := make(map[string]json.RawMessage, len(items))
+		for _, item := range items {
+			var member struct {
+				ID string `json:"id"`
+			}
+			if json.Unmarshal(item, &member) == nil && member.ID != "" {
+				byID[member.ID] = item
+			}
+		}
+
+		b.mu.Lock()
+		b.items[key] = byID
+		b.mu.Unlock()
+		return nil, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-listing:
+	}
+
+	b.mu.Lock()
+	item, ok := b.items[key][id]
+	delete(b.items[key], id)
+	b.mu.Unlock()
+	if ok {
+		return item, nil
+	}
+
+	return b.api.Do(ctx, http.MethodGet, collection+"/"+id, nil)
+}
@@ -0,0 +1,51 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchListingOutlivesCanceledRead(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var individual atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/checks", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte(`{"checks":[{"id":"0000000000000001"},{"id":"0000000000000002"}]}`))
+	})
+	mux.HandleFunc("GET /api/v2/checks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		individual.Add(1)
+		_, _ = w.Write([]byte(`{"id":"` + r.PathValue("id") + `"}`))
+	})
+	batch := NewBatch(newTestClient(t, mux), false)
+
+	// The read that starts the listing gives up while it is running
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := batch.Get(ctx, "checks", "0000000000000aaa", "0000000000000001")
+		canceled <- err
+	}()
+	<-started
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	body, err := batch.Get(context.Background(), "checks", "0000000000000aaa", "0000000000000002")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(body) != `{"id":"0000000000000002"}` {
+		t.Errorf("got body %s", body)
+	}
+	if got := individual.Load(); got != 0 {
+		t.Errorf("got %d individual reads, want the listing to serve the check", got)
+	}
+}
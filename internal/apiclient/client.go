@@ -0,0 +1,76 @@
+// Package apiclient sends InfluxDB API requests which the generated client cannot
+// make, e.g. for polymorphic checks, notification endpoints and notification rules
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Client sends JSON requests to the InfluxDB v2 API
+type Client struct {
+	endpoint string
+	doer     domain.HTTPRequestDoer
+
+	serverMu sync.Mutex
+	server   *Server
+}
+
+// New returns a client reusing the API endpoint and the authenticated transport of
+// the InfluxDB client
+func New(client influxdb2.Client) *Client {
+	apiClient := client.APIClient()
+	return &Client{
+		endpoint: apiClient.APIEndpoint,
+		doer:     apiClient.Client,
+	}
+}
+
+// Do sends a request to the API path relative to /api/v2/, e.g. "checks/<id>", and
+// returns the response body. Bodies are (de)serialized by the caller, which allows
+// decoding polymorphic responses. Non-2xx responses are returned as *Error.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newError(resp.StatusCode, respBody)
+	}
+
+	return respBody, nil
+}
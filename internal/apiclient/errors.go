@@ -0,0 +1,91 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Errors matched by *Error, to be checked with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Error is an error response of the InfluxDB API. Code, Message and Op are taken
+// from the {code, message, op} body InfluxDB returns and are empty for other bodies.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Op         string
+	Body       string
+}
+
+// newError parses an error response body
+func newError(statusCode int, body []byte) *Error {
+	apiErr := &Error{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var response struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Op      string `json:"op"`
+	}
+	if json.Unmarshal(body, &response) == nil {
+		apiErr.Code = response.Code
+		apiErr.Message = response.Message
+		apiErr.Op = response.Op
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" && e.Code != "" {
+		return fmt.Sprintf("InfluxDB API returned status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("InfluxDB API returned status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("InfluxDB API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is reports whether the error matches ErrNotFound, ErrConflict or ErrUnauthorized
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == string(domain.ErrorCodeNotFound)
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.Code == string(domain.ErrorCodeConflict)
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden ||
+			e.Code == string(domain.ErrorCodeUnauthorized) || e.Code == string(domain.ErrorCodeForbidden)
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a not found error of this package or of the
+// generated client, which only returns its errors as "<code>: <message>" strings
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, ErrNotFound) || strings.HasPrefix(err.Error(), string(domain.ErrorCodeNotFound)+":")
+}
+
+// IsConflict reports whether err is a conflict error of this package or of the
+// generated client, e.g. for a name that is already taken
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, ErrConflict) || strings.HasPrefix(err.Error(), string(domain.ErrorCodeConflict)+":")
+}
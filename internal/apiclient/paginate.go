@@ -0,0 +1,83 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageSize is the number of items requested per page when listing a collection
+const PageSize = 100
+
+// apiPrefix is the path prefix of the links in list responses
+const apiPrefix = "/api/v2/"
+
+// listResponse is the envelope of list responses. The items are stored under the
+// collection name, e.g. "checks", next to the links.
+type listResponse struct {
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// ListAll pages through a collection of the API, e.g. "buckets", and returns all of
+// its items. It follows the next link of each page, which uses offsets or cursors
+// depending on the collection, and falls back to limit/offset paging. The query is
+// sent with the first page.
+func (c *Client) ListAll(ctx context.Context, collection string, query url.Values) ([]json.RawMessage, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", strconv.Itoa(PageSize))
+
+	var items, previous []json.RawMessage
+	pagePath := collection + "?" + query.Encode()
+	for offset := 0; ; {
+		body, err := c.Do(ctx, http.MethodGet, pagePath, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response map[string]json.RawMessage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("unable to parse %s response: %w", collection, err)
+		}
+
+		var page []json.RawMessage
+		if raw, ok := response[collection]; ok {
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return nil, fmt.Errorf("unable to parse %s response: %w", collection, err)
+			}
+		}
+
+		// Collections which ignore paging parameters return the same page again
+		if len(page) > 0 && len(previous) > 0 && bytes.Equal(page[0], previous[0]) {
+			return items, nil
+		}
+		items = append(items, page...)
+		previous = page
+
+		// Collections without paging return everything at once
+		if len(page) < PageSize {
+			return items, nil
+		}
+
+		var envelope listResponse
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, fmt.Errorf("unable to parse %s response: %w", collection, err)
+		}
+
+		offset += len(page)
+		nextPath := strings.TrimPrefix(envelope.Links.Next, apiPrefix)
+		if nextPath == "" || nextPath == pagePath {
+			query.Set("offset", strconv.Itoa(offset))
+			nextPath = collection + "?" + query.Encode()
+		}
+		pagePath = nextPath
+	}
+}
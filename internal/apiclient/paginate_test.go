@@ -0,0 +1,138 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// newTestClient returns a client sending its requests to handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := influxdb2.NewClient(server.URL, "test-token")
+	t.Cleanup(client.Close)
+	return New(client)
+}
+
+// bucketPage writes the buckets from offset up to limit of total buckets. When link
+// is set and more buckets follow, the page links to the next one by a cursor
+// instead of an offset.
+func bucketPage(w http.ResponseWriter, query url.Values, total int, link bool) {
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, _ = strconv.Atoi(cursor)
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	page := []map[string]string{}
+	for i := offset; i < total && i < offset+limit; i++ {
+		page = append(page, map[string]string{"id": fmt.Sprintf("%016x", i)})
+	}
+
+	response := map[string]interface{}{"buckets": page}
+	if link && offset+len(page) < total {
+		next := url.Values{}
+		for key, values := range query {
+			next[key] = values
+		}
+		next.Set("cursor", strconv.Itoa(offset+len(page)))
+		response["links"] = map[string]string{"next": "/api/v2/buckets?" + next.Encode()}
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		link      bool
+		wantPages int
+	}{
+		{name: "empty", total: 0, wantPages: 1},
+		{name: "single page", total: 42, wantPages: 1},
+		{name: "exactly one page", total: PageSize, wantPages: 2},
+		{name: "offset paging", total: 2*PageSize + 1, wantPages: 3},
+		{name: "exactly two pages", total: 2 * PageSize, wantPages: 3},
+		{name: "next links", total: 2*PageSize + 1, link: true, wantPages: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pages := 0
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages++
+				if r.URL.Path != "/api/v2/buckets" || r.URL.Query().Get("orgID") != "org" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				if test.link && r.URL.Query().Get("offset") != "" {
+					t.Errorf("request %s did not follow the next link", r.URL)
+				}
+				bucketPage(w, r.URL.Query(), test.total, test.link)
+			}))
+
+			items, err := client.ListAll(context.Background(), "buckets", url.Values{"orgID": {"org"}})
+			if err != nil {
+				t.Fatalf("ListAll failed: %s", err)
+			}
+			if len(items) != test.total {
+				t.Errorf("got %d items, want %d", len(items), test.total)
+			}
+			if pages != test.wantPages {
+				t.Errorf("requested %d pages, want %d", pages, test.wantPages)
+			}
+		})
+	}
+}
+
+func TestListAllIgnoredPaging(t *testing.T) {
+	// Collections ignoring limit and offset return the same page again
+	pages := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		bucketPage(w, url.Values{"limit": {strconv.Itoa(PageSize)}}, PageSize, false)
+	}))
+
+	items, err := client.ListAll(context.Background(), "buckets", nil)
+	if err != nil {
+		t.Fatalf("ListAll failed: %s", err)
+	}
+	if len(items) != PageSize {
+		t.Errorf("got %d items, want %d", len(items), PageSize)
+	}
+	if pages != 2 {
+		t.Errorf("requested %d pages, want 2", pages)
+	}
+}
+
+func TestListAllError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":"forbidden","message":"insufficient permissions"}`))
+			return
+		}
+		bucketPage(w, r.URL.Query(), 2*PageSize, false)
+	}))
+
+	items, err := client.ListAll(context.Background(), "buckets", nil)
+	if err == nil {
+		t.Fatalf("ListAll returned %d items, want error", len(items))
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("got error %v, want status 403", err)
+	}
+}
@@ -0,0 +1,64 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Server flavors as reported in the X-Influxdb-Build header
+const (
+	FlavorOSS   = "OSS"
+	FlavorCloud = "Cloud"
+)
+
+// Server describes the InfluxDB server the client talks to
+type Server struct {
+	Flavor  string
+	Version string
+}
+
+// SupportsManagementAPI reports whether the server manages buckets, tasks, checks
+// and notifications through the v2 API. InfluxDB 1.8 only offers the v2 query and
+// write endpoints for compatibility.
+func (s Server) SupportsManagementAPI() bool {
+	if s.Flavor == FlavorCloud {
+		return true
+	}
+	major, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(s.Version, "v"), ".", 2)[0])
+	return err != nil || major >= 2
+}
+
+// Server detects the flavor and version of the server from the headers of /ping,
+// which every InfluxDB version answers without authentication. The result is
+// cached for the provider run, failures are retried on the next call.
+func (c *Client) Server(ctx context.Context) (Server, error) {
+	c.serverMu.Lock()
+	defer c.serverMu.Unlock()
+	if c.server != nil {
+		return *c.server, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.endpoint, "api/v2/")+"ping", nil)
+	if err != nil {
+		return Server{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return Server{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Server{}, newError(resp.StatusCode, nil)
+	}
+
+	c.server = &Server{
+		Flavor:  resp.Header.Get("X-Influxdb-Build"),
+		Version: resp.Header.Get("X-Influxdb-Version"),
+	}
+	return *c.server, nil
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// circuitBreakerCooldown is how long requests fail fast once the circuit opened,
+// before the next request is let through to probe the server again
+const circuitBreakerCooldown = 30 * time.Second
+
+// circuitBreaker fails requests fast after a number of consecutive failures, so an
+// apply against an unreachable server stops early instead of running into the
+// timeout of every single resource
+type circuitBreaker struct {
+	base      http.RoundTripper
+	threshold int
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// WithCircuitBreaker returns a client sending requests through the transport of
+// client and failing them fast after threshold consecutive failures. Connection
+// errors and 5xx responses count as failures. A threshold of 0 disables it.
+func WithCircuitBreaker(client *http.Client, threshold int) *http.Client {
+	if threshold <= 0 {
+		return client
+	}
+
+	return &http.Client{Transport: &circuitBreaker{
+		base:      client.Transport,
+		threshold: threshold,
+	}}
+}
+
+func (c *circuitBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	if c.failures >= c.threshold && time.Now().Before(c.openUntil) {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("InfluxDB unreachable: %d consecutive requests failed, failing fast until %s", c.failures, c.openUntil.Format(time.RFC3339))
+	}
+	c.mu.Unlock()
+
+	resp, err := c.base.RoundTrip(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Canceled requests, e.g. on resource timeouts, say nothing about the server
+	if (err != nil && req.Context().Err() == nil) || (err == nil && resp.StatusCode >= http.StatusInternalServerError) {
+		c.failures++
+		if c.failures >= c.threshold {
+			c.openUntil = time.Now().Add(circuitBreakerCooldown)
+		}
+	} else if err == nil {
+		c.failures = 0
+	}
+
+	return resp, err
+}
@@ -0,0 +1,134 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusServer answers every request with the current status and counts requests
+type statusServer struct {
+	*httptest.Server
+	status   int
+	requests int
+}
+
+func newStatusServer(t *testing.T, status int) *statusServer {
+	t.Helper()
+
+	server := &statusServer{status: status}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests++
+		w.WriteHeader(server.status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	server := newStatusServer(t, http.StatusInternalServerError)
+	client := WithCircuitBreaker(server.Client(), 3)
+	breaker := client.Transport.(*circuitBreaker)
+
+	// Failures below the threshold are passed through
+	for i := 0; i < 3; i++ {
+		resp, err := get(t, client, server.URL)
+		if err != nil || resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("request %d: got %v, %v, want status 500", i, resp, err)
+		}
+	}
+
+	// The circuit is open, requests fail without reaching the server
+	_, err := get(t, client, server.URL)
+	if err == nil || !strings.Contains(err.Error(), "InfluxDB unreachable") {
+		t.Fatalf("got error %v, want fail fast", err)
+	}
+	if server.requests != 3 {
+		t.Errorf("server received %d requests, want 3", server.requests)
+	}
+
+	// After the cooldown one request probes the server and closes the circuit
+	server.status = http.StatusOK
+	breaker.openUntil = time.Now().Add(-time.Second)
+	if resp, err := get(t, client, server.URL); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("got %v, %v, want status 200", resp, err)
+	}
+	if breaker.failures != 0 {
+		t.Errorf("got %d failures after a success, want 0", breaker.failures)
+	}
+}
+
+func TestCircuitBreakerReopensAfterFailedProbe(t *testing.T) {
+	server := newStatusServer(t, http.StatusBadGateway)
+	client := WithCircuitBreaker(server.Client(), 2)
+	breaker := client.Transport.(*circuitBreaker)
+
+	for i := 0; i < 2; i++ {
+		_, _ = get(t, client, server.URL)
+	}
+	breaker.openUntil = time.Now().Add(-time.Second)
+
+	// The probe fails, so the circuit opens again
+	if resp, err := get(t, client, server.URL); err != nil || resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("got %v, %v, want status 502", resp, err)
+	}
+	if _, err := get(t, client, server.URL); err == nil {
+		t.Fatal("got no error, want fail fast")
+	}
+	if server.requests != 3 {
+		t.Errorf("server received %d requests, want 3", server.requests)
+	}
+}
+
+func TestCircuitBreakerResets(t *testing.T) {
+	server := newStatusServer(t, http.StatusServiceUnavailable)
+	client := WithCircuitBreaker(server.Client(), 2)
+
+	// Failures only count when they are consecutive, client errors are successes
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusNotFound, http.StatusServiceUnavailable, http.StatusOK} {
+		server.status = status
+		if _, err := get(t, client, server.URL); err != nil {
+			t.Fatalf("status %d: got error %v", status, err)
+		}
+	}
+}
+
+func TestCircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	server := newStatusServer(t, http.StatusOK)
+	client := WithCircuitBreaker(server.Client(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("got no error for a canceled request")
+	}
+
+	if _, err := get(t, client, server.URL); err != nil {
+		t.Errorf("got error %v, canceled requests must not open the circuit", err)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	client := &http.Client{}
+	if WithCircuitBreaker(client, 0) != client {
+		t.Error("a threshold of 0 must return the client unchanged")
+	}
+}
@@ -0,0 +1,19 @@
+package common
+
+import "strings"
+
+// NormalizeFlux removes blank lines and the leading and trailing whitespace of
+// every line, which InfluxDB may change without changing the script
+func NormalizeFlux(flux string) string {
+	lines := strings.Split(flux, "\n")
+	var normalizedLines []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" {
+			normalizedLines = append(normalizedLines, trimmed)
+		}
+	}
+
+	return strings.Join(normalizedLines, "\n")
+}
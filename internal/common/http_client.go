@@ -0,0 +1,22 @@
+package common
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+)
+
+// NewHTTPClient returns the HTTP client shared by all requests of the provider, to
+// InfluxDB as well as to notification destinations. Connections are pooled across
+// resources. Requests are bounded by their context, e.g. the resource timeouts, so
+// the client sets no timeout of its own. Every request is traced when tracing is
+// enabled.
+func NewHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 100
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return &http.Client{Transport: tracing.Transport(transport)}
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBody is the number of body bytes read for trace logging, longer bodies
+// are not logged
+const maxLoggedBody = 64 * 1024
+
+// requestLogger logs requests with tflog, so they show up with TF_LOG=DEBUG and
+// their bodies with TF_LOG=TRACE
+type requestLogger struct {
+	base        http.RoundTripper
+	hideURLPath bool
+}
+
+// WithLogging returns a client logging every request sent through the transport of
+// client: method, URL, status and duration at debug level, the request and
+// response bodies at trace level. Secrets in JSON bodies are redacted and other
+// bodies are not logged. Headers are never logged. With hideURLPath only the scheme
+// and host of URLs are logged, for destinations such as Slack webhooks whose paths
+// are secrets.
+func WithLogging(client *http.Client, hideURLPath bool) *http.Client {
+	return &http.Client{Transport: &requestLogger{
+		base:        client.Transport,
+		hideURLPath: hideURLPath,
+	}}
+}
+
+func (l *requestLogger) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    l.loggedURL(req),
+	}
+
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody = readLogged(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := l.base.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "HTTP request failed", fields)
+		return resp, err
+	}
+	fields["status"] = resp.StatusCode
+	tflog.Debug(ctx, "HTTP request", fields)
+
+	// Only the logged part of the body is read ahead, the caller reads the rest
+	responseBody := readLogged(resp.Body)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(responseBody), resp.Body), resp.Body}
+
+	tflog.Trace(ctx, "HTTP request bodies", map[string]interface{}{
+		"method":        req.Method,
+		"url":           fields["url"],
+		"request_body":  loggedBody(requestBody),
+		"response_body": loggedBody(responseBody),
+	})
+
+	return resp, nil
+}
+
+func (l *requestLogger) loggedURL(req *http.Request) string {
+	if l.hideURLPath {
+		return req.URL.Scheme + "://" + req.URL.Host
+	}
+	return req.URL.Redacted()
+}
+
+// readLogged reads up to one byte more than is logged, so loggedBody can tell
+// truncated bodies apart
+func readLogged(body io.Reader) []byte {
+	prefix, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	return prefix
+}
+
+// loggedBody returns the redacted JSON body or a placeholder for bodies which
+// cannot be redacted
+func loggedBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(body) > maxLoggedBody {
+		return fmt.Sprintf("(more than %d bytes, not logged)", maxLoggedBody)
+	}
+	if redacted, ok := RedactJSON(body); ok {
+		return redacted
+	}
+	return fmt.Sprintf("(%d bytes of non-JSON content, not logged)", len(body))
+}
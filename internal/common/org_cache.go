@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// orgLookupTimeout bounds shared organization lookups, which do not end with the
+// context of the resource that started them
+const orgLookupTimeout = 2 * time.Minute
+
+// orgIDPattern matches InfluxDB organization IDs, which are 16 lowercase hex characters
+var orgIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// IsOrgID reports whether the value looks like an organization ID rather than a name
+func IsOrgID(value string) bool {
+	return orgIDPattern.MatchString(value)
+}
+
+// OrgCache caches organization name and ID lookups for the lifetime of the
+// provider, so an apply resolves each organization only once. It is safe for
+// concurrent use by resources, concurrent misses for the same organization share
+// a single API call.
+type OrgCache struct {
+	client    influxdb2.Client
+	lookups   singleflight.Group
+	mu        sync.Mutex
+	idsByName map[string]string
+	namesByID map[string]string
+}
+
+// NewOrgCache returns an empty cache looking up organizations through client
+func NewOrgCache(client influxdb2.Client) *OrgCache {
+	return &OrgCache{
+		client:    client,
+		idsByName: map[string]string{},
+		namesByID: map[string]string{},
+	}
+}
+
+// Resolve returns the ID of an organization given by name or ID. IDs are returned
+// as-is, skipping the name lookup.
+func (c *OrgCache) Resolve(ctx context.Context, nameOrID string) (string, error) {
+	if IsOrgID(nameOrID) {
+		return nameOrID, nil
+	}
+	return c.IDByName(ctx, nameOrID)
+}
+
+// IDByName returns the ID of the organization with the given name
+func (c *OrgCache) IDByName(ctx context.Context, name string) (string, error) {
+	c.mu.Lock()
+	id, ok := c.idsByName[name]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	// The lookup runs unlocked so slow requests do not block other resources
+	return c.lookup(ctx, "name:"+name, func(ctx context.Context) (string, error) {
+		org, err := c.client.OrganizationsAPI().FindOrganizationByName(ctx, name)
+		if err != nil {
+			return "", err
+		}
+
+		c.store(org.Name, *org.Id)
+		return *org.Id, nil
+	})
+}
+
+// NameByID returns the name of the organization with the given ID
+func (c *OrgCache) NameByID(ctx context.Context, id string) (string, error) {
+	c.mu.Lock()
+	name, ok := c.namesByID[id]
+	c.mu.Unlock()
+	if ok {
+		return name, nil
+	}
+
+	return c.lookup(ctx, "id:"+id, func(ctx context.Context) (string, error) {
+		org, err := c.client.OrganizationsAPI().FindOrganizationByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		c.store(org.Name, *org.Id)
+		return org.Name, nil
+	})
+}
+
+// lookup runs fn once for concurrent callers with the same key. The shared call
+// must not fail because the resource that started it timed out, so it runs
+// without the caller's cancellation and with its own timeout, while each caller
+// stops waiting when its own context ends.
+func (c *OrgCache) lookup(ctx context.Context, key string, fn func(ctx context.Context) (string, error)) (string, error) {
+	results := c.lookups.DoChan(key, func() (interface{}, error) {
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), orgLookupTimeout)
+		defer cancel()
+		return fn(lookupCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
+	}
+}
+
+func (c *OrgCache) store(name, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idsByName[name] = id
+	c.namesByID[id] = name
+}
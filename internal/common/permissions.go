@@ -0,0 +1,24 @@
+package common
+
+import "github.com/influxdata/influxdb-client-go/v2/domain"
+
+// PermissionResourceTypes are the resource types an authorization can grant access to
+var PermissionResourceTypes = []string{
+	string(domain.ResourceTypeAuthorizations),
+	string(domain.ResourceTypeBuckets),
+	string(domain.ResourceTypeChecks),
+	string(domain.ResourceTypeDashboards),
+	string(domain.ResourceTypeDbrp),
+	string(domain.ResourceTypeDocuments),
+	string(domain.ResourceTypeLabels),
+	string(domain.ResourceTypeNotificationEndpoints),
+	string(domain.ResourceTypeNotificationRules),
+	string(domain.ResourceTypeOrgs),
+	string(domain.ResourceTypeSecrets),
+	string(domain.ResourceTypeSources),
+	string(domain.ResourceTypeTasks),
+	string(domain.ResourceTypeTelegrafs),
+	string(domain.ResourceTypeUsers),
+	string(domain.ResourceTypeVariables),
+	string(domain.ResourceTypeViews),
+}
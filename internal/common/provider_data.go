@@ -1,13 +1,40 @@
 package common
 
 import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 )
 
+// ProviderData is the configured provider state handed to every resource and data
+// source. API sends the requests the generated client cannot make, Batch serves
+// refreshes from listings and Orgs caches organization lookups. All of them share
+// the transport settings of HTTPClient, which sends requests to other services
+// such as notification destinations.
+//
+// Without URL or token the clients are left unset and Unconfigured holds the
+// errors to report once an operation needs the API, so validate and plans
+// without refresh work without credentials.
 type ProviderData struct {
-	Client influxdb2.Client
-	Org    string
-	Bucket string
-	Token  string
-	URL    string
+	Client     influxdb2.Client
+	API        *apiclient.Client
+	Batch      *apiclient.Batch
+	Orgs       *OrgCache
+	HTTPClient *http.Client
+	Org        string
+	Bucket     string
+	Token      string
+	URL        string
+	// ReadOnly refuses every operation modifying InfluxDB
+	ReadOnly bool
+	// AdoptExisting lets Create take over an existing object with the same name
+	// instead of failing with a conflict
+	AdoptExisting bool
+	// IgnoreNormalization hides differences InfluxDB normalizes away from plans
+	IgnoreNormalization bool
+
+	Unconfigured diag.Diagnostics
 }
@@ -0,0 +1,23 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// CheckWritable reports an error when the provider is read-only, so operations
+// modifying InfluxDB are refused before sending any request. It returns whether
+// the operation may proceed.
+func CheckWritable(diags *diag.Diagnostics, readOnly bool, operation, typeName string) bool {
+	if !readOnly {
+		return true
+	}
+
+	diags.AddError(
+		"Read-Only Provider",
+		fmt.Sprintf("Unable to %s %s: the provider is configured as read-only and does not modify InfluxDB. "+
+			"Unset read_only and the INFLUXDB_READ_ONLY environment variable to apply changes.", operation, typeName),
+	)
+	return false
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Redacted replaces secret values in logs and recorded API calls
+const Redacted = "REDACTED"
+
+// secretKeys are the JSON keys whose string values are redacted, compared
+// case-insensitively
+var secretKeys = map[string]bool{
+	"authorization": true,
+	"password":      true,
+	"routingkey":    true,
+	"secret":        true,
+	"token":         true,
+}
+
+// RedactJSON replaces the values of secret keys such as tokens and passwords in a
+// JSON body. The body is re-encoded with sorted keys. It reports false for bodies
+// which are not JSON and therefore cannot be redacted.
+func RedactJSON(body []byte) (string, bool) {
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return "", false
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return "", false
+	}
+	return string(redacted), true
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if _, isString := nested.(string); isString && secretKeys[strings.ToLower(key)] {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
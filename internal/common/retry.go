@@ -0,0 +1,105 @@
+package common
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xing/terraform-provider-influxdb/internal/metrics"
+)
+
+// maxRetryDelay caps the exponential backoff and Retry-After delays
+const maxRetryDelay = 30 * time.Second
+
+// retrier resends requests InfluxDB rejected with a transient error, waiting
+// exponentially longer between attempts
+type retrier struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// WithRetries returns a client resending requests through the transport of client
+// up to maxRetries times. 429 and 503 responses are always retried, as InfluxDB
+// did not process the request. 502 and 504 responses are only retried for methods
+// other than POST, since the request may have been processed and a repeated
+// create would fail with a conflict. The first retry waits baseDelay, each further
+// retry twice as long, unless the response sets Retry-After.
+func WithRetries(client *http.Client, maxRetries int, baseDelay time.Duration) *http.Client {
+	if maxRetries <= 0 {
+		return client
+	}
+
+	return &http.Client{Transport: &retrier{
+		base:       client.Transport,
+		maxRetries: maxRetries,
+		baseDelay:  baseDelay,
+	}}
+}
+
+func (r *retrier) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := r.base.RoundTrip(attemptReq)
+		if err != nil || attempt >= r.maxRetries || !retryable(req.Method, resp.StatusCode) {
+			return resp, err
+		}
+
+		// Bodies can only be sent again if the request can recreate them
+		hasBody := req.Body != nil && req.Body != http.NoBody
+		if hasBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		delay := retryDelay(resp, r.baseDelay, attempt)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		// The caller's request must not be modified, so every retry sends a clone
+		// with a fresh body
+		attemptReq = req.Clone(req.Context())
+		if hasBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+		metrics.RecordRetry()
+	}
+}
+
+// retryable reports whether a response status is a transient error worth retrying
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// retryDelay returns the delay before the next attempt, preferring the seconds of
+// a Retry-After header over the exponential backoff
+func retryDelay(resp *http.Response, baseDelay time.Duration, attempt int) time.Duration {
+	// Doubling stops at the cap, shifting by the attempt could overflow
+	delay := baseDelay
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	if delay < 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,175 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// replayServer answers requests with the given statuses in order, repeating the
+// last one, and records the received bodies
+type replayServer struct {
+	*httptest.Server
+	statuses []int
+	bodies   []string
+}
+
+func newReplayServer(t *testing.T, statuses ...int) *replayServer {
+	t.Helper()
+
+	server := &replayServer{statuses: statuses}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		server.bodies = append(server.bodies, string(body))
+
+		status := server.statuses[len(server.statuses)-1]
+		if len(server.bodies) <= len(server.statuses) {
+			status = server.statuses[len(server.bodies)-1]
+		}
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantStatus   int
+	}{
+		{name: "success", method: http.MethodGet, statuses: []int{200}, maxRetries: 3, wantRequests: 1, wantStatus: 200},
+		{name: "unavailable", method: http.MethodGet, statuses: []int{503, 503, 200}, maxRetries: 3, wantRequests: 3, wantStatus: 200},
+		{name: "too many requests", method: http.MethodPost, statuses: []int{429, 201}, maxRetries: 3, wantRequests: 2, wantStatus: 201},
+		{name: "bad gateway", method: http.MethodPut, statuses: []int{502, 200}, maxRetries: 3, wantRequests: 2, wantStatus: 200},
+		{name: "gateway timeout", method: http.MethodDelete, statuses: []int{504, 204}, maxRetries: 3, wantRequests: 2, wantStatus: 204},
+		{name: "bad gateway on create", method: http.MethodPost, statuses: []int{502, 201}, maxRetries: 3, wantRequests: 1, wantStatus: 502},
+		{name: "internal error", method: http.MethodGet, statuses: []int{500, 200}, maxRetries: 3, wantRequests: 1, wantStatus: 500},
+		{name: "not found", method: http.MethodGet, statuses: []int{404, 200}, maxRetries: 3, wantRequests: 1, wantStatus: 404},
+		{name: "retries exhausted", method: http.MethodGet, statuses: []int{503}, maxRetries: 2, wantRequests: 3, wantStatus: 503},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newReplayServer(t, test.statuses...)
+			client := WithRetries(server.Client(), test.maxRetries, time.Millisecond)
+
+			var body io.Reader
+			if test.method == http.MethodPost || test.method == http.MethodPut {
+				body = strings.NewReader(`{"name":"telegraf"}`)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), test.method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, test.wantStatus)
+			}
+			if len(server.bodies) != test.wantRequests {
+				t.Errorf("server received %d requests, want %d", len(server.bodies), test.wantRequests)
+			}
+			// Every attempt sends the complete body
+			for i, received := range server.bodies {
+				if body != nil && received != `{"name":"telegraf"}` {
+					t.Errorf("attempt %d sent body %q", i, received)
+				}
+			}
+		})
+	}
+}
+
+func TestRetriesKeepRequest(t *testing.T) {
+	server := newReplayServer(t, 503, 200)
+	client := WithRetries(server.Client(), 1, time.Millisecond)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, server.URL, bytes.NewReader([]byte("payload")))
+	body := req.Body
+	if _, err := client.Transport.RoundTrip(req); err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+
+	if req.Body != body {
+		t.Error("the retry replaced the body of the caller's request")
+	}
+}
+
+func TestRetriesWithoutGetBody(t *testing.T) {
+	server := newReplayServer(t, 503, 200)
+	client := WithRetries(server.Client(), 3, time.Millisecond)
+
+	// Bodies which cannot be recreated are not sent twice
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, server.URL, io.NopCloser(strings.NewReader("payload")))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || len(server.bodies) != 1 {
+		t.Errorf("got status %d after %d requests, want 503 after 1", resp.StatusCode, len(server.bodies))
+	}
+}
+
+func TestRetriesCanceled(t *testing.T) {
+	server := newReplayServer(t, 503)
+	client := WithRetries(server.Client(), 3, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("got no error, want the context error while waiting for the retry")
+	}
+	if len(server.bodies) != 1 {
+		t.Errorf("server received %d requests, want 1", len(server.bodies))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{name: "first retry", attempt: 0, want: time.Second},
+		{name: "backoff", attempt: 3, want: 8 * time.Second},
+		{name: "capped backoff", attempt: 10, want: maxRetryDelay},
+		{name: "overflowing backoff", attempt: 62, want: maxRetryDelay},
+		{name: "retry after", retryAfter: "5", attempt: 3, want: 5 * time.Second},
+		{name: "retry after zero", retryAfter: "0", attempt: 3, want: 0},
+		{name: "capped retry after", retryAfter: "3600", want: maxRetryDelay},
+		{name: "retry after date", retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", attempt: 1, want: 2 * time.Second},
+		{name: "negative retry after", retryAfter: "-1", attempt: 1, want: 2 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				resp.Header.Set("Retry-After", test.retryAfter)
+			}
+			if got := retryDelay(resp, time.Second, test.attempt); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
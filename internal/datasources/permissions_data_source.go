@@ -0,0 +1,253 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PermissionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &PermissionsDataSource{}
+
+func NewPermissionsDataSource() datasource.DataSource {
+	return &PermissionsDataSource{}
+}
+
+// PermissionsDataSource expands compact grants such as "read:buckets/telegraf"
+// into the permission objects of the authorization API
+type PermissionsDataSource struct {
+	client       influxdb2.Client
+	org          string
+	orgs         *common.OrgCache
+	unconfigured diag.Diagnostics
+}
+
+// PermissionsDataSourceModel describes the data source data model.
+type PermissionsDataSourceModel struct {
+	Org         types.String      `tfsdk:"org"`
+	Grants      []types.String    `tfsdk:"grants"`
+	Permissions []PermissionModel `tfsdk:"permissions"`
+}
+
+// PermissionModel describes a single expanded permission
+type PermissionModel struct {
+	Action       types.String `tfsdk:"action"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceID   types.String `tfsdk:"resource_id"`
+}
+
+func (d *PermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permissions"
+}
+
+func (d *PermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expands compact grants such as `read:buckets/telegraf` into the permission objects of the InfluxDB authorization API, e.g. for the `permissions` of `influxdb_authorization_token`.",
+
+		Attributes: map[string]schema.Attribute{
+			"org": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Organization name or ID the grants apply to. If not provided, uses the provider default.",
+			},
+			"grants": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Grants in the form `<action>:<resource_type>` or `<action>:<resource_type>/<name or ID>`, e.g. `write:tasks` or `read:buckets/telegraf`. Names are resolved for buckets and tasks, other resources must be given by ID.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Expanded permissions in the order of the grants",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Permitted action (`read` or `write`)",
+						},
+						"resource_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the resources the permission applies to",
+						},
+						"resource_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the single resource the permission applies to, null for all resources of the type",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.org = providerData.Org
+	d.orgs = providerData.Orgs
+	d.unconfigured = providerData.Unconfigured
+}
+
+func (d *PermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	resp.Diagnostics.Append(d.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data PermissionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgName := d.org
+	if !data.Org.IsNull() {
+		orgName = data.Org.ValueString()
+	}
+
+	// The organization is only needed to resolve names
+	orgID := ""
+
+	data.Permissions = make([]PermissionModel, 0, len(data.Grants))
+	for i, grant := range data.Grants {
+		action, resourceType, resource, err := parseGrant(grant.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("grants").AtListIndex(i), "Invalid Grant", err.Error())
+			continue
+		}
+
+		permission := PermissionModel{
+			Action:       types.StringValue(action),
+			ResourceType: types.StringValue(resourceType),
+			ResourceID:   types.StringNull(),
+		}
+
+		if resource != "" {
+			if orgID == "" && !isResourceID(resource) {
+				orgID, err = d.orgs.Resolve(ctx, orgName)
+				if err != nil {
+					resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to find organization '%s', got error: %s", orgName, err))
+					return
+				}
+			}
+
+			id, err := d.resolveResourceID(ctx, orgID, resourceType, resource)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("grants").AtListIndex(i), "Read - Lookup Error", err.Error())
+				continue
+			}
+			permission.ResourceID = types.StringValue(id)
+		}
+
+		data.Permissions = append(data.Permissions, permission)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseGrant splits a grant such as "read:buckets/telegraf" into its action,
+// resource type and optional resource name or ID
+func parseGrant(grant string) (action, resourceType, resource string, err error) {
+	action, target, found := strings.Cut(grant, ":")
+	if !found {
+		return "", "", "", fmt.Errorf("expected <action>:<resource_type>[/<name or ID>], got: %q", grant)
+	}
+
+	if action != string(domain.PermissionActionRead) && action != string(domain.PermissionActionWrite) {
+		return "", "", "", fmt.Errorf("action of grant %q must be read or write", grant)
+	}
+
+	resourceType, resource, _ = strings.Cut(target, "/")
+	if !slices.Contains(common.PermissionResourceTypes, resourceType) {
+		return "", "", "", fmt.Errorf("resource type of grant %q must be one of: %s", grant, strings.Join(common.PermissionResourceTypes, ", "))
+	}
+
+	if strings.HasSuffix(target, "/") {
+		return "", "", "", fmt.Errorf("grant %q is missing the resource name or ID after the slash", grant)
+	}
+
+	return action, resourceType, resource, nil
+}
+
+// isResourceID reports whether the value looks like an InfluxDB ID rather than a name
+func isResourceID(value string) bool {
+	// Resource IDs share the format of organization IDs
+	return common.IsOrgID(value)
+}
+
+// resolveResourceID returns the ID of the resource given by name or ID. Names can
+// only be resolved for buckets and tasks.
+func (d *PermissionsDataSource) resolveResourceID(ctx context.Context, orgID, resourceType, resource string) (string, error) {
+	if isResourceID(resource) {
+		return resource, nil
+	}
+
+	var ids []string
+	switch resourceType {
+	case string(domain.ResourceTypeBuckets):
+		buckets, err := d.client.APIClient().GetBuckets(ctx, &domain.GetBucketsParams{OrgID: &orgID, Name: &resource})
+		if err != nil {
+			return "", fmt.Errorf("unable to look up bucket %q: %w", resource, err)
+		}
+		if buckets.Buckets != nil {
+			for _, bucket := range *buckets.Buckets {
+				if bucket.Id != nil {
+					ids = append(ids, *bucket.Id)
+				}
+			}
+		}
+	case string(domain.ResourceTypeTasks):
+		tasks, err := d.client.APIClient().GetTasks(ctx, &domain.GetTasksParams{OrgID: &orgID, Name: &resource})
+		if err != nil {
+			return "", fmt.Errorf("unable to look up task %q: %w", resource, err)
+		}
+		if tasks.Tasks != nil {
+			for _, task := range *tasks.Tasks {
+				ids = append(ids, task.Id)
+			}
+		}
+	default:
+		return "", fmt.Errorf("%s can only be granted by ID, got: %q", resourceType, resource)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found", resourceType, resource)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d %s named %q, grant by ID instead", len(ids), resourceType, resource)
+	}
+}
@@ -0,0 +1,168 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerConfigDataSource{}
+var _ datasource.DataSourceWithConfigure = &ServerConfigDataSource{}
+
+func NewServerConfigDataSource() datasource.DataSource {
+	return &ServerConfigDataSource{}
+}
+
+// ServerConfigDataSource exposes the runtime configuration and feature flags of
+// the InfluxDB server
+type ServerConfigDataSource struct {
+	api          *apiclient.Client
+	unconfigured diag.Diagnostics
+}
+
+// ServerConfigDataSourceModel describes the data source data model.
+type ServerConfigDataSourceModel struct {
+	Flavor  types.String `tfsdk:"flavor"`
+	Version types.String `tfsdk:"version"`
+	Config  types.Map    `tfsdk:"config"`
+	Flags   types.Map    `tfsdk:"flags"`
+}
+
+func (d *ServerConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_config"
+}
+
+func (d *ServerConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Flavor, version, runtime configuration and feature flags of the InfluxDB server, e.g. to only create resources when the server supports them. Values are strings, with nested values encoded as JSON. Either map is null when the server does not expose it or the token may not read it.",
+
+		Attributes: map[string]schema.Attribute{
+			"flavor": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Server flavor, `OSS` or `Cloud`",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Server version, e.g. `v2.7.1`",
+			},
+			"config": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Runtime configuration from `/api/v2/config`, which requires an operator token and is not available on InfluxDB Cloud",
+			},
+			"flags": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Feature flags from `/api/v2/flags`, e.g. `{\"replicationsEnabled\" = \"true\"}`",
+			},
+		},
+	}
+}
+
+func (d *ServerConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.api = providerData.API
+	d.unconfigured = providerData.Unconfigured
+}
+
+func (d *ServerConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	resp.Diagnostics.Append(d.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data ServerConfigDataSourceModel
+
+	server, err := d.api.Server(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to detect server version, got error: %s", err))
+		return
+	}
+	data.Flavor = types.StringValue(server.Flavor)
+	data.Version = types.StringValue(server.Version)
+
+	config, err := d.fetchSettings(ctx, "config", "config")
+	if err != nil {
+		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to read server configuration, got error: %s", err))
+		return
+	}
+	data.Config = config
+
+	flags, err := d.fetchSettings(ctx, "flags", "")
+	if err != nil {
+		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to read feature flags, got error: %s", err))
+		return
+	}
+	data.Flags = flags
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fetchSettings reads a settings object from the API path, unwrapping it from the
+// envelope key if given. Settings the server does not expose or the token may not
+// read are returned as a null map.
+func (d *ServerConfigDataSource) fetchSettings(ctx context.Context, apiPath, envelope string) (types.Map, error) {
+	body, err := d.api.Do(ctx, http.MethodGet, apiPath, nil)
+	if errors.Is(err, apiclient.ErrNotFound) || errors.Is(err, apiclient.ErrUnauthorized) {
+		return types.MapNull(types.StringType), nil
+	}
+	if err != nil {
+		return types.MapNull(types.StringType), err
+	}
+
+	if envelope != "" {
+		var wrapped map[string]json.RawMessage
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return types.MapNull(types.StringType), fmt.Errorf("unable to parse %s response: %w", apiPath, err)
+		}
+		body = wrapped[envelope]
+		if body == nil {
+			return types.MapNull(types.StringType), nil
+		}
+	}
+
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return types.MapNull(types.StringType), fmt.Errorf("unable to parse %s response: %w", apiPath, err)
+	}
+
+	values := make(map[string]string, len(settings))
+	for key, raw := range settings {
+		// Strings are stored without quotes, everything else as JSON
+		var value string
+		if json.Unmarshal(raw, &value) != nil {
+			value = string(raw)
+		}
+		values[key] = value
+	}
+
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), fmt.Errorf("unable to convert %s response", apiPath)
+	}
+	return mapValue, nil
+}
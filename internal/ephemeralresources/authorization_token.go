@@ -0,0 +1,241 @@
+package ephemeralresources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// authorizationIDKey is the private data key holding the ID of the authorization
+// to revoke when the ephemeral resource is closed
+const authorizationIDKey = "authorization_id"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &AuthorizationTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AuthorizationTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &AuthorizationTokenEphemeralResource{}
+
+func NewAuthorizationTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AuthorizationTokenEphemeralResource{}
+}
+
+// AuthorizationTokenEphemeralResource mints a scoped authorization for the duration
+// of a Terraform run and revokes it afterwards.
+type AuthorizationTokenEphemeralResource struct {
+	client       influxdb2.Client
+	org          string
+	orgs         *common.OrgCache
+	readOnly     bool
+	unconfigured diag.Diagnostics
+}
+
+// AuthorizationTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AuthorizationTokenEphemeralResourceModel struct {
+	Org         types.String      `tfsdk:"org"`
+	Description types.String      `tfsdk:"description"`
+	Permissions []PermissionModel `tfsdk:"permissions"`
+	ID          types.String      `tfsdk:"id"`
+	Token       types.String      `tfsdk:"token"`
+}
+
+// PermissionModel describes a permission of the authorization
+type PermissionModel struct {
+	Action       types.String `tfsdk:"action"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceID   types.String `tfsdk:"resource_id"`
+}
+
+func (r *AuthorizationTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorization_token"
+}
+
+func (r *AuthorizationTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Short-lived InfluxDB authorization token. The authorization is created when Terraform opens the ephemeral resource and deleted again when the run finishes, so the token is never stored in state. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"org": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the authorization, shown in the InfluxDB UI while the run is in progress",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Permissions granted to the token",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Permitted action (`read` or `write`)",
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(domain.PermissionActionRead), string(domain.PermissionActionWrite)),
+							},
+						},
+						"resource_type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Type of the resources the permission applies to, e.g. `buckets`",
+							Validators: []validator.String{
+								stringvalidator.OneOf(common.PermissionResourceTypes...),
+							},
+						},
+						"resource_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "ID of a single resource the permission applies to. If not provided, the permission applies to all resources of the type in the organization.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Authorization ID",
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Authorization token",
+			},
+		},
+	}
+}
+
+func (r *AuthorizationTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.org = providerData.Org
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+}
+
+func (r *AuthorizationTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Minting the token creates an authorization
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "open", "influxdb_authorization_token") {
+		return
+	}
+
+	var data AuthorizationTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgName := r.org
+	if !data.Org.IsNull() {
+		orgName = data.Org.ValueString()
+	}
+
+	orgID, err := r.orgs.Resolve(ctx, orgName)
+	if err != nil {
+		resp.Diagnostics.AddError("Open - Client Error", fmt.Sprintf("Unable to find organization '%s', got error: %s", orgName, err))
+		return
+	}
+
+	permissions := make([]domain.Permission, 0, len(data.Permissions))
+	for _, permission := range data.Permissions {
+		permissions = append(permissions, domain.Permission{
+			Action: domain.PermissionAction(permission.Action.ValueString()),
+			Resource: domain.Resource{
+				Type:  domain.ResourceType(permission.ResourceType.ValueString()),
+				Id:    permission.ResourceID.ValueStringPointer(),
+				OrgID: &orgID,
+			},
+		})
+	}
+
+	description := "Temporary token created by Terraform"
+	if !data.Description.IsNull() {
+		description = data.Description.ValueString()
+	}
+
+	authorization, err := r.client.AuthorizationsAPI().CreateAuthorization(ctx, &domain.Authorization{
+		AuthorizationUpdateRequest: domain.AuthorizationUpdateRequest{
+			Description: &description,
+		},
+		OrgID:       &orgID,
+		Permissions: &permissions,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Open - Client Error", fmt.Sprintf("Unable to create authorization, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringPointerValue(authorization.Id)
+	data.Token = types.StringPointerValue(authorization.Token)
+
+	// Remember the authorization so Close can revoke it
+	authorizationID, err := json.Marshal(authorization.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Open - Serialization Error", fmt.Sprintf("Unable to store authorization ID: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, authorizationIDKey, authorizationID)...)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close revokes the authorization created by Open
+func (r *AuthorizationTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, diags := req.Private.GetKey(ctx, authorizationIDKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		return
+	}
+
+	var authorizationID string
+	if err := json.Unmarshal(value, &authorizationID); err != nil {
+		resp.Diagnostics.AddError("Close - Deserialization Error", fmt.Sprintf("Unable to read authorization ID: %s", err))
+		return
+	}
+
+	if err := r.client.AuthorizationsAPI().DeleteAuthorizationWithID(ctx, authorizationID); err != nil {
+		// Already revoked, e.g. by an operator
+		if apiclient.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Close - Client Error", fmt.Sprintf("Unable to revoke authorization %s, got error: %s", authorizationID, err))
+		return
+	}
+}
@@ -0,0 +1,101 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CheckQueryFunction{}
+
+// checkAggregates are the aggregate functions the check query builder of the
+// InfluxDB UI offers for aggregateWindow
+var checkAggregates = []string{"count", "first", "last", "max", "mean", "median", "min", "spread", "stddev", "sum"}
+
+// checkQueryTemplate is the query the InfluxDB UI generates for checks
+const checkQueryTemplate = `from(bucket: "%s")
+  |> range(start: v.timeRangeStart, stop: v.timeRangeStop)
+  |> filter(fn: (r) => r["_measurement"] == "%s")
+  |> filter(fn: (r) => r["_field"] == "%s")
+  |> aggregateWindow(every: %s, fn: %s, createEmpty: false)
+  |> yield(name: "%s")`
+
+func NewCheckQueryFunction() function.Function {
+	return &CheckQueryFunction{}
+}
+
+// CheckQueryFunction builds the Flux query of a check from its parameters
+type CheckQueryFunction struct{}
+
+func (f *CheckQueryFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "check_query"
+}
+
+func (f *CheckQueryFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the Flux query of a check",
+		MarkdownDescription: "Builds the query the InfluxDB UI generates for a check on a single field, e.g. for `query` of `influxdb_check`: the field is filtered from the bucket and measurement and aggregated per period. Bucket, measurement and field are escaped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "bucket",
+				MarkdownDescription: "Bucket name",
+			},
+			function.StringParameter{
+				Name:                "measurement",
+				MarkdownDescription: "Measurement name",
+			},
+			function.StringParameter{
+				Name:                "field",
+				MarkdownDescription: "Field name",
+			},
+			function.StringParameter{
+				Name:                "aggregate",
+				MarkdownDescription: "Aggregate function, one of `" + strings.Join(checkAggregates, "`, `") + "`",
+			},
+			function.StringParameter{
+				Name:                "period",
+				MarkdownDescription: "InfluxDB duration of the aggregation windows, usually the `every` of the check",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CheckQueryFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket, measurement, field, aggregate, period string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &bucket, &measurement, &field, &aggregate, &period))
+	if resp.Error != nil {
+		return
+	}
+
+	if !slices.Contains(checkAggregates, aggregate) {
+		resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("aggregate must be one of %s, got: %q", strings.Join(checkAggregates, ", "), aggregate))
+		return
+	}
+
+	duration, err := validators.ParseDuration(period)
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("period must be longer than 0s, got: %q", period)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(4, err.Error())
+		return
+	}
+
+	query := fmt.Sprintf(checkQueryTemplate,
+		fluxStringEscaper.Replace(bucket),
+		fluxStringEscaper.Replace(measurement),
+		fluxStringEscaper.Replace(field),
+		validators.FormatDuration(duration),
+		aggregate,
+		aggregate,
+	)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, query))
+}
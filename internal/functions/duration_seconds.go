@@ -0,0 +1,29 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DurationSecondsFunction{}
+
+func NewDurationSecondsFunction() function.Function {
+	return &DurationSecondsFunction{}
+}
+
+// DurationSecondsFunction is duration_to_seconds under the shorter name
+// duration_seconds
+type DurationSecondsFunction struct {
+	DurationToSecondsFunction
+}
+
+func (f *DurationSecondsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration_seconds"
+}
+
+func (f *DurationSecondsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	f.DurationToSecondsFunction.Definition(ctx, req, resp)
+	resp.Definition.MarkdownDescription += " Alias of `duration_to_seconds`."
+}
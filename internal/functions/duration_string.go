@@ -0,0 +1,56 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DurationStringFunction{}
+
+func NewDurationStringFunction() function.Function {
+	return &DurationStringFunction{}
+}
+
+// DurationStringFunction formats whole seconds as an InfluxDB duration literal
+type DurationStringFunction struct{}
+
+func (f *DurationStringFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration_string"
+}
+
+func (f *DurationStringFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Format seconds as an InfluxDB duration",
+		MarkdownDescription: "Formats whole seconds as the shortest InfluxDB duration literal, e.g. `2592000` becomes `30d`, in the same form as `every` and `offset` of tasks and checks are read back. The inverse of `duration_to_seconds` for durations without months or years.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "seconds",
+				MarkdownDescription: "Non-negative number of seconds",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DurationStringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seconds int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &seconds))
+	if resp.Error != nil {
+		return
+	}
+
+	if seconds < 0 || seconds > math.MaxInt64/int64(time.Second) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("seconds must be between 0 and %d, got: %d", math.MaxInt64/int64(time.Second), seconds))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, validators.FormatDuration(time.Duration(seconds)*time.Second)))
+}
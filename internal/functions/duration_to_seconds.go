@@ -0,0 +1,55 @@
+package functions
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DurationToSecondsFunction{}
+
+func NewDurationToSecondsFunction() function.Function {
+	return &DurationToSecondsFunction{}
+}
+
+// DurationToSecondsFunction converts an InfluxDB duration literal to whole seconds
+type DurationToSecondsFunction struct{}
+
+func (f *DurationToSecondsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration_to_seconds"
+}
+
+func (f *DurationToSecondsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an InfluxDB duration to seconds",
+		MarkdownDescription: "Converts an InfluxDB duration literal to whole seconds, e.g. for `retention_seconds` of `influxdb_bucket`. Sub-second parts are truncated. Months and years are counted as 30 and 365 days.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "InfluxDB duration literal such as `30d` or `1h30m`",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *DurationToSecondsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	duration, err := validators.ParseDuration(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, int64(duration/time.Second)))
+}
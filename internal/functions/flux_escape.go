@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &FluxEscapeFunction{}
+
+// fluxStringEscaper escapes the characters that would end or alter a Flux string
+// literal. "${" starts string interpolation in Flux, so its dollar sign is escaped.
+var fluxStringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"${", `\${`,
+)
+
+func NewFluxEscapeFunction() function.Function {
+	return &FluxEscapeFunction{}
+}
+
+// FluxEscapeFunction escapes a string for embedding in a Flux string literal
+type FluxEscapeFunction struct{}
+
+func (f *FluxEscapeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "flux_escape"
+}
+
+func (f *FluxEscapeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Escape a string for Flux",
+		MarkdownDescription: "Escapes backslashes, double quotes, line breaks, tabs and `${` so the value can be embedded between double quotes in a Flux query, e.g. `from(bucket: \"${provider::influxdb::flux_escape(var.bucket)}\")`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "String to escape",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FluxEscapeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fluxStringEscaper.Replace(value)))
+}
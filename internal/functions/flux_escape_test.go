@@ -0,0 +1,37 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFluxEscape(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "telegraf", want: "telegraf"},
+		{name: "empty", value: "", want: ""},
+		{name: "quotes", value: `say "hi"`, want: `say \"hi\"`},
+		{name: "backslash", value: `C:\data`, want: `C:\\data`},
+		{name: "escaped quote", value: `\"`, want: `\\\"`},
+		{name: "line breaks and tabs", value: "a\nb\r\tc", want: `a\nb\r\tc`},
+		{name: "interpolation", value: "${secret}", want: `\${secret}`},
+		{name: "dollar without brace", value: "$5", want: "$5"},
+		{name: "injection", value: `x") |> drop(columns: ["y`, want: `x\") |> drop(columns: [\"y`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runFunction(t, NewFluxEscapeFunction(), types.StringUnknown(), types.StringValue(test.value))
+			if err != nil {
+				t.Fatalf("flux_escape(%q) failed: %s", test.value, err)
+			}
+			if !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("flux_escape(%q) = %s, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &LineProtocolFunction{}
+
+// measurementEscaper escapes the special characters of measurement names
+var measurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+
+// keyEscaper escapes the special characters of tag keys, tag values and field keys
+var keyEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+
+// stringFieldEscaper escapes the special characters of string field values
+var stringFieldEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func NewLineProtocolFunction() function.Function {
+	return &LineProtocolFunction{}
+}
+
+// LineProtocolFunction encodes a single point as InfluxDB line protocol
+type LineProtocolFunction struct{}
+
+func (f *LineProtocolFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "line_protocol"
+}
+
+func (f *LineProtocolFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encode a point as line protocol",
+		MarkdownDescription: "Encodes a single point as InfluxDB line protocol, escaping measurement, tags and fields. Tags and fields are sorted by key. Numbers are written as floats, strings are quoted and booleans are written as `true` or `false`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "measurement",
+				MarkdownDescription: "Measurement name",
+			},
+			function.MapParameter{
+				Name:                "tags",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+				MarkdownDescription: "Tag set of the point, may be `null` or empty",
+			},
+			function.DynamicParameter{
+				Name:                "fields",
+				MarkdownDescription: "Field set of the point as an object or map of numbers, strings and booleans. At least one field is required.",
+			},
+			function.Int64Parameter{
+				Name:                "time",
+				AllowNullValue:      true,
+				MarkdownDescription: "Timestamp of the point in nanoseconds since the Unix epoch. If `null`, InfluxDB uses the time the point is written.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *LineProtocolFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var measurement string
+	var tags map[string]*string
+	var fields types.Dynamic
+	var timestamp *int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &measurement, &tags, &fields, &timestamp))
+	if resp.Error != nil {
+		return
+	}
+
+	if measurement == "" {
+		resp.Error = function.NewArgumentFuncError(0, "measurement must not be empty")
+		return
+	}
+	if strings.ContainsAny(measurement, "\n\r") {
+		resp.Error = function.NewArgumentFuncError(0, "measurement must not contain line breaks")
+		return
+	}
+
+	var line strings.Builder
+	line.WriteString(measurementEscaper.Replace(measurement))
+
+	for _, key := range sortedKeys(tags) {
+		value := tags[key]
+		// Empty tags are not allowed in line protocol, so they are left out
+		if value == nil || *value == "" {
+			continue
+		}
+		if key == "" || strings.ContainsAny(key+*value, "\n\r") {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("tag %q must have a non-empty key without line breaks", key))
+			return
+		}
+		fmt.Fprintf(&line, ",%s=%s", keyEscaper.Replace(key), keyEscaper.Replace(*value))
+	}
+
+	encodedFields, err := encodeFields(fields)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+	line.WriteString(" ")
+	line.WriteString(encodedFields)
+
+	if timestamp != nil {
+		line.WriteString(" ")
+		line.WriteString(strconv.FormatInt(*timestamp, 10))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, line.String()))
+}
+
+// encodeFields encodes the field set of a point, given as an object or map
+func encodeFields(fields types.Dynamic) (string, error) {
+	if fields.IsNull() || fields.IsUnderlyingValueNull() {
+		return "", fmt.Errorf("fields must not be null")
+	}
+
+	var values map[string]attr.Value
+	switch value := fields.UnderlyingValue().(type) {
+	case types.Object:
+		values = value.Attributes()
+	case types.Map:
+		values = value.Elements()
+	default:
+		return "", fmt.Errorf("fields must be an object or map, got: %s", fields.UnderlyingValue().Type(context.Background()))
+	}
+
+	var encoded []string
+	for _, key := range sortedKeys(values) {
+		value, err := encodeFieldValue(values[key])
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", key, err)
+		}
+		// Null fields are left out, like missing fields
+		if value == "" {
+			continue
+		}
+		if key == "" || strings.ContainsAny(key, "\n\r") {
+			return "", fmt.Errorf("field %q must have a non-empty key without line breaks", key)
+		}
+		encoded = append(encoded, keyEscaper.Replace(key)+"="+value)
+	}
+
+	if len(encoded) == 0 {
+		return "", fmt.Errorf("at least one non-null field is required")
+	}
+
+	return strings.Join(encoded, ","), nil
+}
+
+// encodeFieldValue encodes a single field value, returning an empty string for null values
+func encodeFieldValue(value attr.Value) (string, error) {
+	if value.IsNull() {
+		return "", nil
+	}
+
+	switch value := value.(type) {
+	case types.String:
+		return `"` + stringFieldEscaper.Replace(value.ValueString()) + `"`, nil
+	case types.Bool:
+		return strconv.FormatBool(value.ValueBool()), nil
+	case types.Number:
+		number, _ := value.ValueBigFloat().Float64()
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case types.Dynamic:
+		return encodeFieldValue(value.UnderlyingValue())
+	default:
+		return "", fmt.Errorf("unsupported value type %s, expected number, string or bool", value.Type(context.Background()))
+	}
+}
+
+// sortedKeys returns the keys of a map in lexical order, so the encoding is stable
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
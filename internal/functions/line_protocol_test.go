@@ -0,0 +1,160 @@
+package functions
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLineProtocol(t *testing.T) {
+	fields := func(values map[string]attr.Value) types.Dynamic {
+		attributeTypes := make(map[string]attr.Type, len(values))
+		for key, value := range values {
+			attributeTypes[key] = value.Type(context.Background())
+		}
+		return types.DynamicValue(types.ObjectValueMust(attributeTypes, values))
+	}
+	tags := func(values map[string]string) types.Map {
+		elements := make(map[string]attr.Value, len(values))
+		for key, value := range values {
+			elements[key] = types.StringValue(value)
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+	number := func(value float64) types.Number {
+		return types.NumberValue(big.NewFloat(value))
+	}
+
+	tests := []struct {
+		name        string
+		measurement string
+		tags        types.Map
+		fields      types.Dynamic
+		time        types.Int64
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "number field",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields:      fields(map[string]attr.Value{"usage": number(0.5)}),
+			time:        types.Int64Null(),
+			want:        "cpu usage=0.5",
+		},
+		{
+			name:        "sorted tags and fields with time",
+			measurement: "cpu",
+			tags:        tags(map[string]string{"region": "eu", "host": "a"}),
+			fields:      fields(map[string]attr.Value{"user": number(1), "system": number(2)}),
+			time:        types.Int64Value(1700000000000000000),
+			want:        "cpu,host=a,region=eu system=2,user=1 1700000000000000000",
+		},
+		{
+			name:        "escaping",
+			measurement: "disk usage,total",
+			tags:        tags(map[string]string{"mount point": "/var,log=x"}),
+			fields:      fields(map[string]attr.Value{"path name": types.StringValue(`C:\data "main"`)}),
+			time:        types.Int64Null(),
+			want:        `disk\ usage\,total,mount\ point=/var\,log\=x path\ name="C:\\data \"main\""`,
+		},
+		{
+			name:        "bool field and empty tag",
+			measurement: "service",
+			tags:        tags(map[string]string{"env": ""}),
+			fields:      fields(map[string]attr.Value{"up": types.BoolValue(true)}),
+			time:        types.Int64Null(),
+			want:        "service up=true",
+		},
+		{
+			name:        "null fields are skipped",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields:      fields(map[string]attr.Value{"usage": number(1), "idle": types.StringNull()}),
+			time:        types.Int64Null(),
+			want:        "cpu usage=1",
+		},
+		{
+			name:        "map fields",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+				"state": types.StringValue("ok"),
+			})),
+			time: types.Int64Null(),
+			want: `cpu state="ok"`,
+		},
+		{
+			name:        "empty measurement",
+			measurement: "",
+			tags:        types.MapNull(types.StringType),
+			fields:      fields(map[string]attr.Value{"usage": number(1)}),
+			time:        types.Int64Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "line break in measurement",
+			measurement: "cpu\nmem",
+			tags:        types.MapNull(types.StringType),
+			fields:      fields(map[string]attr.Value{"usage": number(1)}),
+			time:        types.Int64Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "line break in tag",
+			measurement: "cpu",
+			tags:        tags(map[string]string{"host": "a\nb"}),
+			fields:      fields(map[string]attr.Value{"usage": number(1)}),
+			time:        types.Int64Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "only null fields",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields:      fields(map[string]attr.Value{"usage": types.StringNull()}),
+			time:        types.Int64Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "fields not an object",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields:      types.DynamicValue(types.StringValue("usage=1")),
+			time:        types.Int64Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported field type",
+			measurement: "cpu",
+			tags:        types.MapNull(types.StringType),
+			fields: fields(map[string]attr.Value{
+				"cores": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+			}),
+			time:    types.Int64Null(),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runFunction(t, NewLineProtocolFunction(), types.StringUnknown(),
+				types.StringValue(test.measurement), test.tags, test.fields, test.time)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("line_protocol() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("line_protocol() failed: %s", err)
+			}
+			if !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("line_protocol() = %s, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeDurationFunction{}
+
+func NewNormalizeDurationFunction() function.Function {
+	return &NormalizeDurationFunction{}
+}
+
+// NormalizeDurationFunction rewrites an InfluxDB duration literal in its shortest form
+type NormalizeDurationFunction struct{}
+
+func (f *NormalizeDurationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_duration"
+}
+
+func (f *NormalizeDurationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize an InfluxDB duration",
+		MarkdownDescription: "Rewrites an InfluxDB duration literal in its shortest form using days, hours, minutes, seconds and sub-second units, e.g. `90s` becomes `1m30s`. Months and years are counted as 30 and 365 days.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "InfluxDB duration literal such as `90s` or `1h30m`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeDurationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	duration, err := validators.ParseDuration(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, validators.FormatDuration(duration)))
+}
@@ -0,0 +1,97 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "90s", want: "1m30s"},
+		{value: "60m", want: "1h"},
+		{value: "1h30m", want: "1h30m"},
+		{value: "0s", want: "0s"},
+		{value: "2w", want: "14d"},
+		{value: "1mo", want: "30d"},
+		{value: "1500ms", want: "1s500ms"},
+		{value: "", wantErr: true},
+		{value: "1.5h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := runFunction(t, NewNormalizeDurationFunction(), types.StringUnknown(), types.StringValue(test.value))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("normalize_duration(%q) = %s, want error", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalize_duration(%q) failed: %s", test.value, err)
+			}
+			if !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("normalize_duration(%q) = %s, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDurationToSeconds(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "0s", want: 0},
+		{value: "90s", want: 90},
+		{value: "1h30m", want: 5400},
+		{value: "30d", want: 2592000},
+		{value: "1y", want: 31536000},
+		{value: "1500ms", want: 1},
+		{value: "999ms", want: 0},
+		{value: "", wantErr: true},
+		{value: "30", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := runFunction(t, NewDurationToSecondsFunction(), types.Int64Unknown(), types.StringValue(test.value))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("duration_to_seconds(%q) = %s, want error", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("duration_to_seconds(%q) failed: %s", test.value, err)
+			}
+			if !got.Equal(types.Int64Value(test.want)) {
+				t.Errorf("duration_to_seconds(%q) = %s, want %d", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDurationSeconds(t *testing.T) {
+	f := NewDurationSecondsFunction()
+
+	metadata := &function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, metadata)
+	if metadata.Name != "duration_seconds" {
+		t.Errorf("got name %q, want duration_seconds", metadata.Name)
+	}
+
+	got, err := runFunction(t, f, types.Int64Unknown(), types.StringValue("30d"))
+	if err != nil || !got.Equal(types.Int64Value(2592000)) {
+		t.Errorf("duration_seconds(\"30d\") = %s, %v, want 2592000", got, err)
+	}
+}
@@ -0,0 +1,48 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeFluxFunction{}
+
+func NewNormalizeFluxFunction() function.Function {
+	return &NormalizeFluxFunction{}
+}
+
+// NormalizeFluxFunction rewrites a Flux script the way tasks compare their scripts
+type NormalizeFluxFunction struct{}
+
+func (f *NormalizeFluxFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_flux"
+}
+
+func (f *NormalizeFluxFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize a Flux script",
+		MarkdownDescription: "Removes blank lines and leading and trailing whitespace from every line of a Flux script, the same normalization `influxdb_task` applies when comparing its `flux`. Scripts from heredocs or `templatefile()` can be compared with it regardless of indentation.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "script",
+				MarkdownDescription: "Flux script",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeFluxFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var script string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &script))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, common.NormalizeFlux(script)))
+}
@@ -0,0 +1,193 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateFluxFunction{}
+
+// fluxClosers maps the opening brackets of Flux to their closing counterparts
+var fluxClosers = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// fluxRegexPredecessors are the characters after which a slash starts a regular
+// expression literal instead of a division
+const fluxRegexPredecessors = "([{,:=~!|>"
+
+func NewValidateFluxFunction() function.Function {
+	return &ValidateFluxFunction{}
+}
+
+// ValidateFluxFunction checks the structure of a Flux script without a server
+type ValidateFluxFunction struct{}
+
+func (f *ValidateFluxFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_flux"
+}
+
+func (f *ValidateFluxFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validate the structure of a Flux script",
+		MarkdownDescription: "Returns the Flux script unchanged and fails the plan if it has unbalanced parentheses, brackets or braces, unterminated strings or regular expressions, or a `|>` without a function after it, e.g. `flux = provider::influxdb::validate_flux(file(\"query.flux\"))`. Functions cannot reach the server, so the script is not type-checked and unknown functions or wrong arguments are only reported by InfluxDB.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "script",
+				MarkdownDescription: "Flux script",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ValidateFluxFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var script string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &script))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := checkFluxStructure(script); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, script))
+}
+
+// fluxPosition is a 1-based line and column in a script
+type fluxPosition struct {
+	line, column int
+}
+
+func (p fluxPosition) String() string {
+	return fmt.Sprintf("line %d, column %d", p.line, p.column)
+}
+
+type fluxBracket struct {
+	char rune
+	at   fluxPosition
+}
+
+// checkFluxStructure scans a script for unbalanced brackets, unterminated string
+// and regular expression literals and dangling pipe-forward operators. Comments
+// and the contents of literals are skipped.
+func checkFluxStructure(script string) error {
+	runes := []rune(script)
+	var open []fluxBracket
+	var pipe *fluxPosition
+	var previous rune
+	line, column := 1, 0
+
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		if char == '\n' {
+			line, column = line+1, 0
+			continue
+		}
+		column++
+		at := fluxPosition{line, column}
+		if strings.ContainsRune(" \t\r", char) {
+			continue
+		}
+
+		// Comments run to the end of the line
+		if char == '/' && i+1 < len(runes) && runes[i+1] == '/' {
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+			continue
+		}
+
+		if pipe != nil {
+			if _, isCloser := openerOf(char); isCloser || (char == '|' && i+1 < len(runes) && runes[i+1] == '>') {
+				return fmt.Errorf("|> at %s is not followed by a function call", pipe)
+			}
+			pipe = nil
+		}
+
+		switch {
+		case char == '"':
+			end := skipLiteral(runes, i, '"', true)
+			if end < 0 {
+				return fmt.Errorf("string starting at %s is never terminated", at)
+			}
+			for _, skipped := range runes[i+1 : end+1] {
+				if skipped == '\n' {
+					line, column = line+1, 0
+				} else {
+					column++
+				}
+			}
+			i = end
+		case char == '/' && (previous == 0 || strings.ContainsRune(fluxRegexPredecessors, previous)):
+			end := skipLiteral(runes, i, '/', false)
+			if end < 0 {
+				return fmt.Errorf("regular expression starting at %s is never terminated", at)
+			}
+			column += end - i
+			i = end
+		case char == '|' && i+1 < len(runes) && runes[i+1] == '>':
+			pipe = &at
+			i++
+			column++
+			char = '>'
+		case fluxClosers[char] != 0:
+			open = append(open, fluxBracket{char: char, at: at})
+		default:
+			if opener, isCloser := openerOf(char); isCloser {
+				if len(open) == 0 {
+					return fmt.Errorf("unexpected %q at %s", char, at)
+				}
+				last := open[len(open)-1]
+				if last.char != opener {
+					return fmt.Errorf("unexpected %q at %s, %q opened at %s is not closed", char, at, last.char, last.at)
+				}
+				open = open[:len(open)-1]
+			}
+		}
+		previous = char
+	}
+
+	if pipe != nil {
+		return fmt.Errorf("|> at %s is not followed by a function call", pipe)
+	}
+	if len(open) > 0 {
+		last := open[len(open)-1]
+		return fmt.Errorf("%q opened at %s is never closed", last.char, last.at)
+	}
+	return nil
+}
+
+// openerOf returns the opening bracket matching a closing bracket
+func openerOf(char rune) (rune, bool) {
+	for opener, closer := range fluxClosers {
+		if closer == char {
+			return opener, true
+		}
+	}
+	return 0, false
+}
+
+// skipLiteral returns the index of the delimiter terminating the literal starting
+// at start, or -1. Backslashes escape the next character. Only strings may span
+// multiple lines.
+func skipLiteral(runes []rune, start int, delimiter rune, multiline bool) int {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '\n':
+			if !multiline {
+				return -1
+			}
+		case delimiter:
+			return i
+		}
+	}
+	return -1
+}
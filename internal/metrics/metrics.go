@@ -0,0 +1,196 @@
+// Package metrics counts the InfluxDB API calls of a provider run and summarizes
+// them when the run ends, so automation can track how close it gets to the request
+// quotas of InfluxDB Cloud. The summary is logged and optionally written as JSON.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiPrefix is the path prefix of InfluxDB API requests
+const apiPrefix = "/api/v2/"
+
+// recorder collects the calls of the provider process, which serves a single
+// Terraform command
+var recorder = &runRecorder{
+	started:    time.Now(),
+	operations: map[string]int{},
+	statuses:   map[int]int{},
+}
+
+// runRecorder accumulates the API calls of a run
+type runRecorder struct {
+	mu          sync.Mutex
+	started     time.Time
+	operations  map[string]int
+	statuses    map[int]int
+	rateLimited int
+	retries     int
+	failed      int
+	latencies   []time.Duration
+
+	// logCtx carries the Terraform logger the summary is written to
+	logCtx context.Context
+	path   string
+}
+
+// Summary is the per-run summary of API calls
+type Summary struct {
+	DurationSeconds float64        `json:"duration_seconds"`
+	Calls           int            `json:"calls"`
+	Operations      map[string]int `json:"operations"`
+	StatusCodes     map[int]int    `json:"status_codes"`
+	RateLimited     int            `json:"rate_limited"`
+	Retries         int            `json:"retries"`
+	Failed          int            `json:"failed"`
+	LatencyMs       Latency        `json:"latency_ms"`
+}
+
+// Latency holds latency percentiles in milliseconds
+type Latency struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// Configure sets where the summary goes: the Terraform logger carried by ctx and,
+// if path is not empty, a JSON file
+func Configure(ctx context.Context, path string) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.logCtx = ctx
+	recorder.path = path
+}
+
+// Transport wraps base so every request is counted
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := base.RoundTrip(req)
+		latency := time.Since(start)
+
+		operation := req.Method + " " + collection(req.URL.Path)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		recorder.record(operation, status, err, latency)
+		return resp, err
+	})
+}
+
+// RecordRetry counts a request that is sent again after a transient error
+func RecordRetry() {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.retries++
+}
+
+// Finish logs the summary of the run and writes it to the configured file. It is
+// called once the provider shuts down.
+func Finish() error {
+	summary := recorder.summary()
+
+	recorder.mu.Lock()
+	logCtx, path := recorder.logCtx, recorder.path
+	recorder.mu.Unlock()
+
+	if logCtx == nil || summary.Calls == 0 {
+		return nil
+	}
+
+	tflog.Info(logCtx, "InfluxDB API usage", map[string]interface{}{
+		"calls":          summary.Calls,
+		"rate_limited":   summary.RateLimited,
+		"retries":        summary.Retries,
+		"failed":         summary.Failed,
+		"latency_p50_ms": summary.LatencyMs.P50,
+		"latency_p99_ms": summary.LatencyMs.P99,
+	})
+
+	if path == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (r *runRecorder) record(operation string, status int, err error, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.operations[operation]++
+	r.latencies = append(r.latencies, latency)
+	if err != nil {
+		r.failed++
+		return
+	}
+	r.statuses[status]++
+	if status == http.StatusTooManyRequests {
+		r.rateLimited++
+	}
+}
+
+func (r *runRecorder) summary() Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latencies := slices.Clone(r.latencies)
+	slices.Sort(latencies)
+
+	return Summary{
+		DurationSeconds: time.Since(r.started).Seconds(),
+		Calls:           len(latencies),
+		Operations:      maps.Clone(r.operations),
+		StatusCodes:     maps.Clone(r.statuses),
+		RateLimited:     r.rateLimited,
+		Retries:         r.retries,
+		Failed:          r.failed,
+		LatencyMs: Latency{
+			P50: percentile(latencies, 0.50),
+			P90: percentile(latencies, 0.90),
+			P99: percentile(latencies, 0.99),
+			Max: percentile(latencies, 1),
+		},
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies in milliseconds
+func percentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := max(0, int(math.Ceil(p*float64(len(sorted))))-1)
+	return float64(sorted[index].Microseconds()) / 1000
+}
+
+// collection returns the API collection of a request path, e.g. "checks" for
+// /api/v2/checks/<id>, so calls for single objects are counted together
+func collection(path string) string {
+	path = strings.TrimPrefix(path, apiPrefix)
+	name, _, _ := strings.Cut(path, "/")
+	return name
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketResource(t *testing.T) {
+	name := testAccName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed("influxdb_bucket", "buckets"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketConfig(name, "created by an acceptance test", 604800),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("influxdb_bucket.test", "id"),
+					resource.TestCheckResourceAttrSet("influxdb_bucket.test", "org_id"),
+					resource.TestCheckResourceAttr("influxdb_bucket.test", "name", name),
+					resource.TestCheckResourceAttr("influxdb_bucket.test", "retention_seconds", "604800"),
+				),
+			},
+			{
+				Config: testAccBucketConfig(name, "updated by an acceptance test", 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("influxdb_bucket.test", "description", "updated by an acceptance test"),
+					resource.TestCheckResourceAttr("influxdb_bucket.test", "retention_seconds", "0"),
+				),
+			},
+			{
+				ResourceName:      "influxdb_bucket.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBucketConfig(name, description string, retentionSeconds int) string {
+	return fmt.Sprintf(`
+resource "influxdb_bucket" "test" {
+  name              = %q
+  description       = %q
+  retention_seconds = %d
+}
+`, name, description, retentionSeconds)
+}
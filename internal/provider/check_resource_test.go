@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCheckResource(t *testing.T) {
+	name := testAccName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed("influxdb_check", "checks"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckConfig(name, "active", 90),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("influxdb_check.test", "id"),
+					resource.TestCheckResourceAttr("influxdb_check.test", "name", name),
+					resource.TestCheckResourceAttr("influxdb_check.test", "thresholds.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("influxdb_check.test", "thresholds.*", map[string]string{
+						"type":  "greater",
+						"value": "90",
+						"level": "CRIT",
+					}),
+				),
+			},
+			// Changing the status patches the check, changing thresholds replaces its definition
+			{
+				Config: testAccCheckConfig(name, "inactive", 90),
+				Check:  resource.TestCheckResourceAttr("influxdb_check.test", "status", "inactive"),
+			},
+			{
+				Config: testAccCheckConfig(name, "inactive", 95.5),
+				Check: resource.TestCheckTypeSetElemNestedAttrs("influxdb_check.test", "thresholds.*", map[string]string{
+					"value": "95.5",
+				}),
+			},
+			{
+				ResourceName:      "influxdb_check.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Run statistics change while the check is scheduled
+				ImportStateVerifyIgnore: []string{"updated_at", "latest_completed", "last_run_status", "last_run_error"},
+			},
+		},
+	})
+}
+
+func testAccCheckConfig(name, status string, threshold float64) string {
+	return fmt.Sprintf(`
+resource "influxdb_bucket" "test" {
+  name = %[1]q
+}
+
+resource "influxdb_check" "test" {
+  name   = %[1]q
+  type   = "threshold"
+  every  = "1m"
+  status = %[2]q
+  query  = <<-EOT
+    from(bucket: "${influxdb_bucket.test.name}")
+      |> range(start: -1m)
+      |> filter(fn: (r) => r._measurement == "cpu")
+      |> filter(fn: (r) => r._field == "usage_user")
+      |> aggregateWindow(every: 1m, fn: mean, createEmpty: false)
+  EOT
+
+  thresholds {
+    type  = "greater"
+    value = %[3]g
+    level = "CRIT"
+  }
+}
+`, name, status, threshold)
+}
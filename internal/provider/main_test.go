@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Settings of the InfluxDB container started for acceptance tests, matching the
+// instance from `make influxdb-up`
+const (
+	testAccImage  = "influxdb:2.7"
+	testAccOrg    = "acc-test"
+	testAccBucket = "acc-test"
+	testAccToken  = "acc-test-token"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+// runTests runs the tests of the package. Acceptance tests without an InfluxDB
+// given by INFLUXDB_URL run against a disposable container, which requires Docker.
+func runTests(m *testing.M) int {
+	if os.Getenv("TF_ACC") == "" || os.Getenv("INFLUXDB_URL") != "" {
+		return m.Run()
+	}
+
+	ctx := context.Background()
+	container, err := startInfluxDB(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to start InfluxDB for acceptance tests, set INFLUXDB_URL to use a running instance: %s\n", err)
+		return 1
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to remove the InfluxDB container: %s\n", err)
+		}
+	}()
+
+	return m.Run()
+}
+
+// startInfluxDB starts an onboarded InfluxDB 2.x container and points the
+// provider to it through the INFLUXDB_* environment variables
+func startInfluxDB(ctx context.Context) (_ testcontainers.Container, err error) {
+	// testcontainers panics when it finds no Docker host
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	image := os.Getenv("INFLUXDB_IMAGE")
+	if image == "" {
+		image = testAccImage
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        image,
+			ExposedPorts: []string{"8086/tcp"},
+			Env: map[string]string{
+				"DOCKER_INFLUXDB_INIT_MODE":        "setup",
+				"DOCKER_INFLUXDB_INIT_USERNAME":    "admin",
+				"DOCKER_INFLUXDB_INIT_PASSWORD":    "acc-test-password",
+				"DOCKER_INFLUXDB_INIT_ORG":         testAccOrg,
+				"DOCKER_INFLUXDB_INIT_BUCKET":      testAccBucket,
+				"DOCKER_INFLUXDB_INIT_ADMIN_TOKEN": testAccToken,
+			},
+			// The entrypoint only serves port 8086 once onboarding is complete
+			WaitingFor: wait.ForHTTP("/health").WithPort("8086/tcp").WithStartupTimeout(2 * time.Minute),
+		},
+		Started: true,
+	})
+	if err != nil {
+		if container != nil {
+			_ = container.Terminate(ctx)
+		}
+		return nil, err
+	}
+
+	url, err := container.PortEndpoint(ctx, "8086/tcp", "http")
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, err
+	}
+
+	for name, value := range map[string]string{
+		"INFLUXDB_URL":    url,
+		"INFLUXDB_TOKEN":  testAccToken,
+		"INFLUXDB_ORG":    testAccOrg,
+		"INFLUXDB_BUCKET": testAccBucket,
+	} {
+		if err := os.Setenv(name, value); err != nil {
+			_ = container.Terminate(ctx)
+			return nil, err
+		}
+	}
+	return container, nil
+}
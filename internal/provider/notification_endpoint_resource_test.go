@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNotificationEndpointResource(t *testing.T) {
+	name := testAccName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed("influxdb_notification_endpoint", "notificationEndpoints"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationEndpointConfig(name, "https://example.com/alerts", "POST"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("influxdb_notification_endpoint.test", "id"),
+					resource.TestCheckResourceAttr("influxdb_notification_endpoint.test", "name", name),
+					resource.TestCheckResourceAttr("influxdb_notification_endpoint.test", "type", "http"),
+					resource.TestCheckResourceAttr("influxdb_notification_endpoint.test", "headers.Content-Type", "application/json"),
+				),
+			},
+			{
+				Config: testAccNotificationEndpointConfig(name, "https://example.com/other-alerts", "PUT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("influxdb_notification_endpoint.test", "url", "https://example.com/other-alerts"),
+					resource.TestCheckResourceAttr("influxdb_notification_endpoint.test", "method", "PUT"),
+				),
+			},
+			{
+				ResourceName:            "influxdb_notification_endpoint.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at"},
+			},
+		},
+	})
+}
+
+func testAccNotificationEndpointConfig(name, url, method string) string {
+	return fmt.Sprintf(`
+resource "influxdb_notification_endpoint" "test" {
+  name        = %q
+  type        = "http"
+  url         = %q
+  method      = %q
+  auth_method = "none"
+  headers = {
+    "Content-Type" = "application/json"
+  }
+}
+`, name, url, method)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNotificationRuleResource(t *testing.T) {
+	name := testAccName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed("influxdb_notification_rule", "notificationRules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationRuleConfig(name, "1m", "CRIT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("influxdb_notification_rule.test", "id"),
+					resource.TestCheckResourceAttrPair("influxdb_notification_rule.test", "endpoint_id", "influxdb_notification_endpoint.test", "id"),
+					resource.TestCheckResourceAttr("influxdb_notification_rule.test", "status_rules.0.current_level", "CRIT"),
+				),
+			},
+			{
+				Config: testAccNotificationRuleConfig(name, "5m", "WARN"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("influxdb_notification_rule.test", "every", "5m"),
+					resource.TestCheckResourceAttr("influxdb_notification_rule.test", "status_rules.0.current_level", "WARN"),
+				),
+			},
+			{
+				ResourceName:      "influxdb_notification_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Run statistics change while the rule is scheduled
+				ImportStateVerifyIgnore: []string{"updated_at", "latest_completed"},
+			},
+		},
+	})
+}
+
+func testAccNotificationRuleConfig(name, every, level string) string {
+	return fmt.Sprintf(`
+resource "influxdb_notification_endpoint" "test" {
+  name        = %[1]q
+  type        = "http"
+  url         = "https://example.com/alerts"
+  method      = "POST"
+  auth_method = "none"
+}
+
+resource "influxdb_notification_rule" "test" {
+  name        = %[1]q
+  type        = "http"
+  endpoint_id = influxdb_notification_endpoint.test.id
+  every       = %[2]q
+
+  status_rules {
+    current_level = %[3]q
+  }
+}
+`, name, every, level)
+}
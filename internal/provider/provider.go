@@ -2,20 +2,43 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/datasources"
+	"github.com/xing/terraform-provider-influxdb/internal/ephemeralresources"
+	"github.com/xing/terraform-provider-influxdb/internal/functions"
+	"github.com/xing/terraform-provider-influxdb/internal/metrics"
 	"github.com/xing/terraform-provider-influxdb/internal/resources"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+	"github.com/xing/terraform-provider-influxdb/internal/vcr"
 )
 
 // Ensure InfluxDBProvider satisfies various provider interfaces.
 var _ provider.Provider = &InfluxDBProvider{}
+var _ provider.ProviderWithActions = &InfluxDBProvider{}
+var _ provider.ProviderWithEphemeralResources = &InfluxDBProvider{}
+var _ provider.ProviderWithFunctions = &InfluxDBProvider{}
+var _ provider.ProviderWithListResources = &InfluxDBProvider{}
 
 // InfluxDBProvider defines the provider implementation.
 type InfluxDBProvider struct {
@@ -27,10 +50,18 @@ type InfluxDBProvider struct {
 
 // InfluxDBProviderModel describes the provider data model.
 type InfluxDBProviderModel struct {
-	URL    types.String `tfsdk:"url"`
-	Token  types.String `tfsdk:"token"`
-	Org    types.String `tfsdk:"org"`
-	Bucket types.String `tfsdk:"bucket"`
+	URL                     types.String `tfsdk:"url"`
+	Token                   types.String `tfsdk:"token"`
+	Org                     types.String `tfsdk:"org"`
+	Bucket                  types.String `tfsdk:"bucket"`
+	ReadOnly                types.Bool   `tfsdk:"read_only"`
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`
+	BatchRefresh            types.Bool   `tfsdk:"batch_refresh"`
+	IgnoreNormalization     types.Bool   `tfsdk:"ignore_normalization"`
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	MetricsFile             types.String `tfsdk:"metrics_file"`
+	MaxRetries              types.Int64  `tfsdk:"max_retries"`
+	RetryBaseDelay          types.String `tfsdk:"retry_base_delay"`
 }
 
 func (p *InfluxDBProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -58,6 +89,47 @@ func (p *InfluxDBProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "Default InfluxDB Bucket",
 				Optional:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When creating a bucket or check fails because one with the same name already exists, take over the existing one and update it to the configuration instead of failing. Eases moving objects created by hand under Terraform management.",
+				Optional:            true,
+			},
+			"batch_refresh": schema.BoolAttribute{
+				MarkdownDescription: "Refresh tasks, checks, notification endpoints and notification rules from one paged listing per organization instead of reading each of them individually, which speeds up refreshes of organizations with hundreds of them. Defaults to `true`.",
+				Optional:            true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed requests to InfluxDB, i.e. connection errors or 5xx responses, after which further requests fail fast with an \"InfluxDB unreachable\" error for 30 seconds instead of each running into its timeout. Disabled when not set or 0.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ignore_normalization": schema.BoolAttribute{
+				MarkdownDescription: "Ignore differences InfluxDB normalizes away instead of planning them as changes: whitespace in task Flux and notification rule templates, and durations such as `60m` instead of `1h`. Resources can override it with their own `ignore_normalization`. Defaults to `true`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times requests rejected by InfluxDB with 429 or 503, and except for creates with 502 or 504, are sent again before failing. Defaults to `3`, `0` disables retries.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"metrics_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON file the provider writes a summary of its InfluxDB API calls to when Terraform finishes: call counts per operation, status codes, rate-limited calls and latency percentiles. The summary is always logged at info level. Can also be set with the `INFLUXDB_METRICS_FILE` environment variable.",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to create, update or delete anything while still reading from InfluxDB, e.g. to plan changes from untrusted branches against production. Setting the `INFLUXDB_READ_ONLY` environment variable to `true` enables it regardless of the configuration.",
+				Optional:            true,
+			},
+			"retry_base_delay": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry, e.g. `500ms`. Each further retry waits twice as long, up to 30 seconds, unless InfluxDB sends a `Retry-After` header. Defaults to `1s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
 		},
 	}
 }
@@ -71,12 +143,36 @@ func (p *InfluxDBProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// Values of resources created in the same run, e.g. the URL of a new InfluxDB
+	// instance, are unknown until apply. Terraform can defer everything depending
+	// on the provider to a later run instead of failing the plan.
+	if data.URL.IsUnknown() || data.Token.IsUnknown() || data.Org.IsUnknown() || data.Bucket.IsUnknown() ||
+		data.ReadOnly.IsUnknown() || data.AdoptExisting.IsUnknown() || data.BatchRefresh.IsUnknown() ||
+		data.IgnoreNormalization.IsUnknown() || data.CircuitBreakerThreshold.IsUnknown() || data.MetricsFile.IsUnknown() ||
+		data.MaxRetries.IsUnknown() || data.RetryBaseDelay.IsUnknown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unknown InfluxDB Provider Configuration",
+			"The provider cannot create the InfluxDB client as the configuration depends on values that are only known after apply. "+
+				"Apply the resources the provider configuration depends on first, e.g. with -target, "+
+				"or use a Terraform version supporting deferred actions.",
+		)
+		return
+	}
+
 	// Configuration values are now available.
 	// Example client configuration for data sources and resources
 	url := os.Getenv("INFLUXDB_URL")
 	token := os.Getenv("INFLUXDB_TOKEN")
 	org := os.Getenv("INFLUXDB_ORG")
 	bucket := os.Getenv("INFLUXDB_BUCKET")
+	metricsFile := os.Getenv("INFLUXDB_METRICS_FILE")
 
 	if !data.URL.IsNull() {
 		url = data.URL.ValueString()
@@ -94,8 +190,22 @@ func (p *InfluxDBProvider) Configure(ctx context.Context, req provider.Configure
 		bucket = data.Bucket.ValueString()
 	}
 
+	if !data.MetricsFile.IsNull() {
+		metricsFile = data.MetricsFile.ValueString()
+	}
+
+	// The environment variable cannot be overridden by the configuration, so a
+	// pipeline can enforce read-only plans on configuration it does not trust
+	readOnly := data.ReadOnly.ValueBool()
+	if envReadOnly, err := strconv.ParseBool(os.Getenv("INFLUXDB_READ_ONLY")); err == nil && envReadOnly {
+		readOnly = true
+	}
+
+	// Missing credentials are only reported once an operation needs the API, so
+	// validate and plans without refresh work in pipelines without access
+	var unconfigured diag.Diagnostics
 	if url == "" {
-		resp.Diagnostics.AddError(
+		unconfigured.AddError(
 			"Missing InfluxDB URL",
 			"The provider cannot create the InfluxDB client as there is a missing or empty value for the InfluxDB URL. "+
 				"Set the url value in the configuration or use the INFLUXDB_URL environment variable. "+
@@ -104,7 +214,7 @@ func (p *InfluxDBProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	if token == "" {
-		resp.Diagnostics.AddError(
+		unconfigured.AddError(
 			"Missing InfluxDB Token",
 			"The provider cannot create the InfluxDB client as there is a missing or empty value for the InfluxDB Token. "+
 				"Set the token value in the configuration or use the INFLUXDB_TOKEN environment variable. "+
@@ -112,27 +222,60 @@ func (p *InfluxDBProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if err := tracing.Setup(ctx, p.version); err != nil {
+		resp.Diagnostics.AddWarning("Tracing Disabled", fmt.Sprintf("Unable to set up the OpenTelemetry exporter: %s", err))
 	}
 
-	client := influxdb2.NewClient(url, token)
+	httpClient := common.NewHTTPClient()
 
-	// Store client in provider data for use in data sources and resources
-	resp.DataSourceData = &common.ProviderData{
-		Client: client,
-		Org:    org,
-		Bucket: bucket,
-		Token:  token,
-		URL:    url,
+	// Share one provider data value between data sources and resources
+	providerData := &common.ProviderData{
+		HTTPClient:          common.WithLogging(httpClient, true),
+		Org:                 org,
+		Bucket:              bucket,
+		Token:               token,
+		URL:                 url,
+		ReadOnly:            readOnly,
+		AdoptExisting:       data.AdoptExisting.ValueBool(),
+		IgnoreNormalization: data.IgnoreNormalization.IsNull() || data.IgnoreNormalization.ValueBool(),
+		Unconfigured:        unconfigured,
 	}
-	resp.ResourceData = &common.ProviderData{
-		Client: client,
-		Org:    org,
-		Bucket: bucket,
-		Token:  token,
-		URL:    url,
+	if !unconfigured.HasError() {
+		transport, err := vcr.FromEnv(httpClient.Transport)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid VCR Configuration", err.Error())
+			return
+		}
+
+		maxRetries := 3
+		if !data.MaxRetries.IsNull() {
+			maxRetries = int(data.MaxRetries.ValueInt64())
+		}
+		retryBaseDelay := time.Second
+		if !data.RetryBaseDelay.IsNull() {
+			// The validator already rejected invalid durations
+			retryBaseDelay, _ = validators.ParseDuration(data.RetryBaseDelay.ValueString())
+		}
+
+		metrics.Configure(ctx, metricsFile)
+		influxHTTPClient := common.WithRetries(
+			common.WithCircuitBreaker(
+				common.WithLogging(&http.Client{Transport: metrics.Transport(transport)}, false),
+				int(data.CircuitBreakerThreshold.ValueInt64()),
+			),
+			maxRetries, retryBaseDelay,
+		)
+		client := influxdb2.NewClientWithOptions(url, token, influxdb2.DefaultOptions().SetHTTPClient(influxHTTPClient))
+		providerData.Client = client
+		providerData.API = apiclient.New(client)
+		providerData.Batch = apiclient.NewBatch(providerData.API, !data.BatchRefresh.IsNull() && !data.BatchRefresh.ValueBool())
+		providerData.Orgs = common.NewOrgCache(client)
 	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+	resp.ListResourceData = providerData
+	resp.ActionData = providerData
 }
 
 func (p *InfluxDBProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -145,9 +288,47 @@ func (p *InfluxDBProvider) Resources(ctx context.Context) []func() resource.Reso
 	}
 }
 
+func (p *InfluxDBProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		resources.NewBucketListResource,
+		resources.NewTaskListResource,
+		resources.NewCheckListResource,
+		resources.NewNotificationEndpointListResource,
+		resources.NewNotificationRuleListResource,
+	}
+}
+
+func (p *InfluxDBProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		resources.NewTaskRunAction,
+		resources.NewNotificationEndpointTestAction,
+	}
+}
+
+func (p *InfluxDBProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemeralresources.NewAuthorizationTokenEphemeralResource,
+	}
+}
+
+func (p *InfluxDBProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewNormalizeDurationFunction,
+		functions.NewDurationToSecondsFunction,
+		functions.NewDurationSecondsFunction,
+		functions.NewDurationStringFunction,
+		functions.NewNormalizeFluxFunction,
+		functions.NewValidateFluxFunction,
+		functions.NewCheckQueryFunction,
+		functions.NewLineProtocolFunction,
+		functions.NewFluxEscapeFunction,
+	}
+}
+
 func (p *InfluxDBProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// We'll add data sources here later
+		datasources.NewPermissionsDataSource,
+		datasources.NewServerConfigDataSource,
 	}
 }
 
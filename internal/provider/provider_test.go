@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+// testAccProtoV6ProviderFactories serves the provider in-process to the Terraform
+// CLI run by acceptance tests
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"influxdb": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccPreCheck fails acceptance tests without an InfluxDB to run against, e.g.
+// the instance from `make influxdb-up`
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{"INFLUXDB_URL", "INFLUXDB_TOKEN", "INFLUXDB_ORG"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests", name)
+		}
+	}
+}
+
+// testAccName returns a unique name with the prefix removed by `make sweep`
+func testAccName() string {
+	return acctest.RandomWithPrefix("tf-acc-")
+}
+
+// testAccCheckDestroyed verifies that the objects of all resources of the type in
+// the state are gone from the collection
+func testAccCheckDestroyed(resourceType, collection string) func(*terraform.State) error {
+	return func(state *terraform.State) error {
+		client := influxdb2.NewClient(os.Getenv("INFLUXDB_URL"), os.Getenv("INFLUXDB_TOKEN"))
+		defer client.Close()
+		api := apiclient.New(client)
+
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			_, err := api.Do(context.Background(), http.MethodGet, collection+"/"+rs.Primary.ID, nil)
+			if err == nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+			if !apiclient.IsNotFound(err) {
+				return fmt.Errorf("unable to read %s %s: %w", resourceType, rs.Primary.ID, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTaskResource(t *testing.T) {
+	name := testAccName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed("influxdb_task", "tasks"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTaskConfig(name, "1h", "active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("influxdb_task.test", "id"),
+					resource.TestCheckResourceAttr("influxdb_task.test", "name", name),
+					resource.TestCheckResourceAttr("influxdb_task.test", "every", "1h"),
+					resource.TestCheckResourceAttr("influxdb_task.test", "status", "active"),
+				),
+			},
+			{
+				Config: testAccTaskConfig(name, "2h", "inactive"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("influxdb_task.test", "every", "2h"),
+					resource.TestCheckResourceAttr("influxdb_task.test", "status", "inactive"),
+				),
+			},
+			{
+				ResourceName:            "influxdb_task.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at"},
+			},
+		},
+	})
+}
+
+func testAccTaskConfig(name, every, status string) string {
+	return fmt.Sprintf(`
+resource "influxdb_bucket" "test" {
+  name = %[1]q
+}
+
+resource "influxdb_task" "test" {
+  name   = %[1]q
+  every  = %[2]q
+  status = %[3]q
+  flux   = <<-EOT
+    option task = { name: "%[1]s", every: %[2]s }
+
+    from(bucket: "${influxdb_bucket.test.name}")
+      |> range(start: -task.every)
+      |> count()
+      |> to(bucket: "${influxdb_bucket.test.name}")
+  EOT
+}
+`, name, every, status)
+}
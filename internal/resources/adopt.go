@@ -0,0 +1,24 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+)
+
+// adoptableID returns the ID of the existing object whose name made a Create fail
+// with a conflict. Adopting is only safe when exactly one object has the name.
+func adoptableID(ctx context.Context, lookup nameLookup, kind, orgID, name string) (string, error) {
+	ids, err := lookup(ctx, orgID, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to look up existing %s %q: %w", kind, name, err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no existing %s named %q found to adopt", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d existing %ss named %q, import one of them by ID instead", len(ids), kind, name)
+	}
+}
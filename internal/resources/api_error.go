@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+// attributeHint maps a word of InfluxDB error messages, usually an API field name,
+// to the attribute it refers to
+type attributeHint struct {
+	pattern   *regexp.Regexp
+	attribute string
+}
+
+// hint returns an attribute hint matching keyword as a word, singular or plural.
+// Hints are package level variables, so their patterns are compiled once.
+func hint(keyword, attribute string) attributeHint {
+	return attributeHint{
+		pattern:   regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(keyword) + `s?\b`),
+		attribute: attribute,
+	}
+}
+
+var bucketErrorHints = []attributeHint{
+	hint("retention", "retention_seconds"),
+	hint("shardGroupDuration", "retention_seconds"),
+	hint("description", "description"),
+	hint("name", "name"),
+}
+
+var taskErrorHints = []attributeHint{
+	hint("flux", "flux"),
+	hint("compilation", "flux"),
+	hint("query", "flux"),
+	hint("cron", "cron"),
+	hint("every", "every"),
+	hint("offset", "offset"),
+	hint("name", "name"),
+}
+
+var checkErrorHints = []attributeHint{
+	hint("query", "query"),
+	hint("flux", "query"),
+	hint("statusMessageTemplate", "status_message_template"),
+	hint("threshold", "thresholds"),
+	hint("every", "every"),
+	hint("offset", "offset"),
+	hint("name", "name"),
+}
+
+var endpointErrorHints = []attributeHint{
+	hint("url", "url"),
+	hint("routingKey", "routing_key"),
+	hint("clientURL", "client_url"),
+	hint("token", "token"),
+	hint("username", "username"),
+	hint("password", "password"),
+	hint("headers", "headers"),
+	hint("method", "method"),
+	hint("authMethod", "auth_method"),
+	hint("name", "name"),
+}
+
+var ruleErrorHints = []attributeHint{
+	hint("endpointID", "endpoint_id"),
+	hint("endpoint", "endpoint_id"),
+	hint("messageTemplate", "message_template"),
+	hint("statusRules", "status_rules"),
+	hint("tagRules", "tag_rules"),
+	hint("channel", "channel"),
+	hint("every", "every"),
+	hint("offset", "offset"),
+	hint("name", "name"),
+}
+
+// addAPIError reports a failed API request. When the InfluxDB error message
+// mentions one of the hints, the diagnostic is attached to its attribute so
+// Terraform points at the offending configuration.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err error, hints []attributeHint) {
+	if attribute, ok := attributeForError(err, hints); ok {
+		diags.AddAttributeError(path.Root(attribute), summary, detail)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// attributeForError returns the attribute of the first hint matching the error
+// message
+func attributeForError(err error, hints []attributeHint) (string, bool) {
+	// Responses without an InfluxDB error body only carry the status code
+	message := err.Error()
+	var apiErr *apiclient.Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Message == "" {
+			return "", false
+		}
+		message = apiErr.Message
+	}
+
+	for _, h := range hints {
+		if h.pattern.MatchString(message) {
+			return h.attribute, true
+		}
+	}
+	return "", false
+}
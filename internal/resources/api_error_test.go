@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+func TestAttributeForError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		hints         []attributeHint
+		wantAttribute string
+	}{
+		{
+			name:          "field name",
+			err:           &apiclient.Error{StatusCode: 422, Message: "invalid statusMessageTemplate"},
+			hints:         checkErrorHints,
+			wantAttribute: "status_message_template",
+		},
+		{
+			name:          "plural",
+			err:           &apiclient.Error{StatusCode: 422, Message: "thresholds must not be empty"},
+			hints:         checkErrorHints,
+			wantAttribute: "thresholds",
+		},
+		{
+			name:          "case insensitive",
+			err:           &apiclient.Error{StatusCode: 400, Message: "Retention policy must be at least 1h"},
+			hints:         bucketErrorHints,
+			wantAttribute: "retention_seconds",
+		},
+		{
+			name:          "first hint wins",
+			err:           &apiclient.Error{StatusCode: 422, Message: "query name is invalid"},
+			hints:         checkErrorHints,
+			wantAttribute: "query",
+		},
+		{
+			name:          "more specific hint first",
+			err:           &apiclient.Error{StatusCode: 422, Message: "endpointID is required"},
+			hints:         ruleErrorHints,
+			wantAttribute: "endpoint_id",
+		},
+		{
+			name:  "whole words only",
+			err:   &apiclient.Error{StatusCode: 422, Message: "unnamed error"},
+			hints: bucketErrorHints,
+		},
+		{
+			name:  "no message",
+			err:   &apiclient.Error{StatusCode: 500, Body: "name"},
+			hints: bucketErrorHints,
+		},
+		{
+			name:          "generated client error",
+			err:           errors.New("invalid: flux compilation failed"),
+			hints:         taskErrorHints,
+			wantAttribute: "flux",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attribute, ok := attributeForError(test.err, test.hints)
+			if attribute != test.wantAttribute || ok != (test.wantAttribute != "") {
+				t.Errorf("got %q, %t, want %q", attribute, ok, test.wantAttribute)
+			}
+		})
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	var diags diag.Diagnostics
+	addAPIError(&diags, "Create - HTTP Error", "detail", &apiclient.Error{StatusCode: 422, Message: "every must be a duration"}, checkErrorHints)
+	addAPIError(&diags, "Create - HTTP Error", "detail", &apiclient.Error{StatusCode: 500}, checkErrorHints)
+
+	if len(diags) != 2 {
+		t.Fatalf("got %d diagnostics, want 2", len(diags))
+	}
+	if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("every")) {
+		t.Errorf("got %v, want an error on every", diags[0])
+	}
+	if _, ok := diags[1].(diag.DiagnosticWithPath); ok {
+		t.Errorf("got %v, want an error without attribute", diags[1])
+	}
+}
+
+func TestCreateReportsUnprocessableAttribute(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "checks", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusUnprocessableEntity, "unprocessable entity", "failed to compile query: undefined identifier fron")
+	})
+
+	r := NewCheckResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, thresholdCheckAttributes())
+
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("got diagnostics %v, want one error", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("query")) {
+		t.Errorf("got %v, want an error on query", resp.Diagnostics[0])
+	}
+}
@@ -4,37 +4,61 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/domain"
 
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketResource{}
 var _ resource.ResourceWithImportState = &BucketResource{}
+var _ resource.ResourceWithIdentity = &BucketResource{}
+var _ resource.ResourceWithModifyPlan = &BucketResource{}
+var _ list.ListResourceWithConfigure = &BucketResource{}
 
 func NewBucketResource() resource.Resource {
 	return &BucketResource{}
 }
 
+func NewBucketListResource() list.ListResource {
+	return &BucketResource{}
+}
+
 // BucketResource defines the resource implementation.
 type BucketResource struct {
-	client influxdb2.Client
-	org    string
+	client        influxdb2.Client
+	org           string
+	url           string
+	orgs          *common.OrgCache
+	api           *apiclient.Client
+	readOnly      bool
+	adoptExisting bool
+	unconfigured  diag.Diagnostics
 }
 
 // BucketResourceModel describes the resource data model.
 type BucketResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Org              types.String `tfsdk:"org"`
-	Description      types.String `tfsdk:"description"`
-	RetentionSeconds types.Int64  `tfsdk:"retention_seconds"`
+	ID               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	Org              types.String   `tfsdk:"org"`
+	OrgID            types.String   `tfsdk:"org_id"`
+	Description      types.String   `tfsdk:"description"`
+	RetentionSeconds types.Int64    `tfsdk:"retention_seconds"`
+	SkipDestroy      types.Bool     `tfsdk:"skip_destroy"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *BucketResource) setRetentionSecondsFromRules(data *BucketResourceModel, retentionRules []domain.RetentionRule) {
@@ -75,6 +99,9 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Bucket ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -83,7 +110,18 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"org": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default. Moving the bucket to another organization forces a new bucket to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Organization ID. Can be used instead of `org` to skip the organization name lookup, e.g. with tokens that cannot read organizations. Takes precedence over `org` when both are set, e.g. in configuration generated on import.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
@@ -94,10 +132,36 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				MarkdownDescription: "Data retention period in seconds. 0 means infinite retention. Defaults to 0 (infinite).",
 			},
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only remove the bucket from the Terraform state on destroy and keep it in InfluxDB. Only takes effect once applied, before the destroy. Defaults to `false`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
+func (r *BucketResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema()
+}
+
+// ModifyPlan rejects new buckets on servers without bucket management and replaces
+// the bucket when it moves to another organization, which the API cannot do in
+// place. Switching between the name and the ID of the same organization is not a
+// move.
+func (r *BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireManagementAPI(ctx, r.api, r.unconfigured, req, resp, "influxdb_bucket")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireReplaceOnOrgChange(ctx, req, resp, r.orgs, r.org, r.unconfigured)
+}
+
 func (r *BucketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -108,13 +172,19 @@ func (r *BucketResource) Configure(ctx context.Context, req resource.ConfigureRe
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
 	r.client = providerData.Client
 	r.org = providerData.Org
+	r.url = providerData.URL
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+	r.adoptExisting = providerData.AdoptExisting
+	r.api = providerData.API
 }
 
 func (resource *BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -127,17 +197,31 @@ func (resource *BucketResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// Use provider org if not specified
-	orgName := resource.org
-	if !data.Org.IsNull() {
-		orgName = data.Org.ValueString()
+	createTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_bucket", "Create", "")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resource.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, resource.readOnly, "create", "influxdb_bucket") {
+		return
 	}
 
-	// Resolve organization name to ID
-	orgsAPI := resource.client.OrganizationsAPI()
-	org, err := orgsAPI.FindOrganizationByName(ctx, orgName)
+	// Resolve organization name to ID, using the provider org if not specified
+	orgID, err := resolveOrgID(ctx, resource.orgs, data.OrgID, data.Org, resource.org)
 	if err != nil {
-		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to find organization '%s', got error: %s", orgName, err))
+		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to resolve organization, got error: %s", err))
 		return
 	}
 
@@ -146,7 +230,7 @@ func (resource *BucketResource) Create(ctx context.Context, req resource.CreateR
 
 	bucket := &domain.Bucket{
 		Name:           data.Name.ValueString(),
-		OrgID:          org.Id,
+		OrgID:          &orgID,
 		RetentionRules: retentionRules,
 	}
 
@@ -154,15 +238,33 @@ func (resource *BucketResource) Create(ctx context.Context, req resource.CreateR
 
 	bucketsAPI := resource.client.BucketsAPI()
 	createdBucket, err := bucketsAPI.CreateBucket(ctx, bucket)
+	if err != nil && resource.adoptExisting && apiclient.IsConflict(err) {
+		// Take over the bucket of the same name, updating it to the configuration
+		existingID, adoptErr := adoptableID(ctx, resource.bucketIDsByName, "bucket", orgID, bucket.Name)
+		if adoptErr != nil {
+			resp.Diagnostics.AddError("Create - Adopt Error", fmt.Sprintf("Unable to adopt existing bucket: %s", adoptErr))
+			return
+		}
+		bucket.Id = &existingID
+		createdBucket, err = bucketsAPI.UpdateBucket(ctx, bucket)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to create bucket, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Create - Client Error", fmt.Sprintf("Unable to create bucket, got error: %s", err), err, bucketErrorHints)
 		return
 	}
 
 	// Save data into Terraform state
 	data.ID = types.StringValue(*createdBucket.Id)
 	data.Name = types.StringValue(createdBucket.Name)
-	data.Org = types.StringValue(orgName) // Keep the original organization name/identifier that was used in config
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Report the provider default org, or the ID when the bucket was created by org_id
+		if resource.org != "" && data.OrgID.IsUnknown() {
+			data.Org = types.StringValue(resource.org)
+		} else {
+			data.Org = types.StringValue(orgID)
+		}
+	}
+	data.OrgID = types.StringValue(orgID)
 	if createdBucket.Description != nil {
 		data.Description = types.StringValue(*createdBucket.Description)
 	}
@@ -170,6 +272,7 @@ func (resource *BucketResource) Create(ctx context.Context, req resource.CreateR
 	// Save retention policy (use first retention rule)
 	resource.setRetentionSecondsFromRules(&data, createdBucket.RetentionRules)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, resource.url, data.ID)...)
 	setDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(setDiags...)
 }
@@ -184,9 +287,29 @@ func (resource *BucketResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_bucket", "Read", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resource.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get bucket by ID
 	bucketsAPI := resource.client.BucketsAPI()
 	bucket, err := bucketsAPI.FindBucketByID(ctx, data.ID.ValueString())
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to read bucket, got error: %s", err))
 		return
@@ -195,13 +318,12 @@ func (resource *BucketResource) Read(ctx context.Context, req resource.ReadReque
 	// Update data from API response
 	data.Name = types.StringValue(bucket.Name)
 
-	orgsAPI := resource.client.OrganizationsAPI()
-	org, err := orgsAPI.FindOrganizationByID(ctx, *bucket.OrgID)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find organization with ID '%s', got error: %s", *bucket.OrgID, err))
-		return
+	// A bucket never moves between organizations, so the name is only resolved
+	// when it is not known yet, e.g. after import
+	data.OrgID = types.StringPointerValue(bucket.OrgID)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		data.Org = types.StringValue(orgNameOrID(ctx, resource.orgs, *bucket.OrgID))
 	}
-	data.Org = types.StringValue(org.Name)
 
 	if bucket.Description != nil {
 		data.Description = types.StringValue(*bucket.Description)
@@ -212,6 +334,12 @@ func (resource *BucketResource) Read(ctx context.Context, req resource.ReadReque
 	// Read retention policy (check if rules exist)
 	resource.setRetentionSecondsFromRules(&data, bucket.RetentionRules)
 
+	// skip_destroy only exists in Terraform, e.g. an import starts without it
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, resource.url, data.ID)...)
 	readSetDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(readSetDiags...)
 }
@@ -226,6 +354,33 @@ func (resource *BucketResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// Use the ID from state, the planned ID is only known when the plan kept it
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &data.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, timeoutDiags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_bucket", "Update", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resource.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, resource.readOnly, "update", "influxdb_bucket") {
+		return
+	}
+
 	// Prepare retention rules for update
 	retentionRules := resource.prepareRetentionRules(&data)
 
@@ -241,7 +396,7 @@ func (resource *BucketResource) Update(ctx context.Context, req resource.UpdateR
 	bucketsAPI := resource.client.BucketsAPI()
 	updatedBucket, err := bucketsAPI.UpdateBucket(ctx, bucket)
 	if err != nil {
-		resp.Diagnostics.AddError("Update - Client Error", fmt.Sprintf("Unable to update bucket, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Update - Client Error", fmt.Sprintf("Unable to update bucket, got error: %s", err), err, bucketErrorHints)
 		return
 	}
 
@@ -253,6 +408,7 @@ func (resource *BucketResource) Update(ctx context.Context, req resource.UpdateR
 
 	resource.setRetentionSecondsFromRules(&data, updatedBucket.RetentionRules)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, resource.url, data.ID)...)
 	updateSetDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(updateSetDiags...)
 }
@@ -267,17 +423,84 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// The bucket is kept in InfluxDB, Terraform drops it from the state
+	if data.SkipDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_bucket", "Delete", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "delete", "influxdb_bucket") {
+		return
+	}
+
 	// Delete bucket
 	bucketsAPI := r.client.BucketsAPI()
 	err := bucketsAPI.DeleteBucket(ctx, &domain.Bucket{Id: data.ID.ValueStringPointer()})
-	if err != nil {
+	if err != nil && !apiclient.IsNotFound(err) {
 		resp.Diagnostics.AddError("Delete - Client Error", fmt.Sprintf("Unable to delete bucket, got error: %s", err))
 		return
 	}
 }
 
 func (r *BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import using bucket ID
-	diags := resp.State.SetAttribute(ctx, path.Root("id"), req.ID)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Import using bucket ID or org/name
+	importByIDOrName(ctx, req, resp, r.orgs, r.org, r.url, "bucket", r.bucketIDsByName)
+}
+
+// bucketIDsByName returns the IDs of the buckets with the given name in an organization
+func (r *BucketResource) bucketIDsByName(ctx context.Context, orgID, name string) ([]string, error) {
+	buckets, err := r.client.APIClient().GetBuckets(ctx, &domain.GetBucketsParams{
+		OrgID: &orgID,
+		Name:  &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if buckets.Buckets != nil {
+		for _, bucket := range *buckets.Buckets {
+			if bucket.Id != nil {
+				ids = append(ids, *bucket.Id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (r *BucketResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listConfigSchema()
+}
+
+func (r *BucketResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.unconfigured.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(r.unconfigured)
+		return
+	}
+
+	listCollection(ctx, req, stream, r, r.api, r.orgs, r.org, r.url, "buckets", func(member listMember) bool {
+		// System buckets such as _monitoring are managed by InfluxDB itself
+		return member.Type != "system"
+	})
 }
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// typeDiscriminator is used to peek at the type of polymorphic API bodies
+type typeDiscriminator struct {
+	Type string `json:"type"`
+}
+
+// checkThreshold is the wire format of all threshold types. The generated
+// threshold types store values as float32, which would round configured values.
+// Only range thresholds use min, max and within.
+type checkThreshold struct {
+	Type      string   `json:"type"`
+	Value     *float64 `json:"value,omitempty"`
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Within    *bool    `json:"within,omitempty"`
+	Level     string   `json:"level,omitempty"`
+	AllValues bool     `json:"allValues"`
+}
+
+// decodeCheck decodes a check body into the generated domain type matching its
+// type. The generated domain.Check wrapper embeds an interface and therefore
+// cannot decode polymorphic check bodies on its own.
+func decodeCheck(body []byte) (domain.CheckDiscriminator, error) {
+	var discriminator typeDiscriminator
+	if err := json.Unmarshal(body, &discriminator); err != nil {
+		return nil, err
+	}
+
+	switch discriminator.Type {
+	case string(domain.ThresholdCheckTypeThreshold):
+		var raw struct {
+			domain.ThresholdCheck
+			Thresholds []json.RawMessage `json:"thresholds"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, err
+		}
+
+		thresholds := make([]domain.Threshold, len(raw.Thresholds))
+		for i, rawThreshold := range raw.Thresholds {
+			threshold, err := decodeThreshold(rawThreshold)
+			if err != nil {
+				return nil, err
+			}
+			thresholds[i] = threshold
+		}
+
+		check := raw.ThresholdCheck
+		check.Thresholds = &thresholds
+		return &check, nil
+	case string(domain.DeadmanCheckTypeDeadman):
+		var check domain.DeadmanCheck
+		if err := json.Unmarshal(body, &check); err != nil {
+			return nil, err
+		}
+		return &check, nil
+	default:
+		return nil, fmt.Errorf("unsupported check type %q", discriminator.Type)
+	}
+}
+
+// checkBase returns the fields shared by all check types
+func checkBase(check domain.CheckDiscriminator) *domain.CheckBase {
+	switch c := check.(type) {
+	case *domain.ThresholdCheck:
+		return &c.CheckBase
+	case *domain.DeadmanCheck:
+		return &c.CheckBase
+	}
+	return nil
+}
+
+// copyServerManagedFields copies the owner and task of the current check into a
+// check built from the configuration, which has neither
+func copyServerManagedFields(check, current domain.CheckDiscriminator) {
+	base, currentBase := checkBase(check), checkBase(current)
+	if base == nil || currentBase == nil {
+		return
+	}
+
+	base.OwnerID = currentBase.OwnerID
+	base.TaskID = currentBase.TaskID
+}
+
+// checkOrgID returns the organization ID of a decoded check
+func checkOrgID(check domain.CheckDiscriminator) string {
+	switch c := check.(type) {
+	case *domain.ThresholdCheck:
+		return c.OrgID
+	case *domain.DeadmanCheck:
+		return c.OrgID
+	}
+	return ""
+}
+
+// decodeThreshold decodes a threshold, rejecting unknown threshold types
+func decodeThreshold(body []byte) (checkThreshold, error) {
+	var threshold checkThreshold
+	if err := json.Unmarshal(body, &threshold); err != nil {
+		return threshold, err
+	}
+
+	switch threshold.Type {
+	case string(domain.GreaterThresholdTypeGreater), string(domain.LesserThresholdTypeLesser), string(domain.RangeThresholdTypeRange):
+		return threshold, nil
+	default:
+		return threshold, fmt.Errorf("unsupported threshold type %q", threshold.Type)
+	}
+}
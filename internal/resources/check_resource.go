@@ -1,38 +1,62 @@
 package resources
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CheckResource{}
 var _ resource.ResourceWithImportState = &CheckResource{}
+var _ resource.ResourceWithIdentity = &CheckResource{}
+var _ list.ListResourceWithConfigure = &CheckResource{}
+var _ resource.ResourceWithConfigValidators = &CheckResource{}
+var _ resource.ResourceWithModifyPlan = &CheckResource{}
+var _ resource.ResourceWithUpgradeState = &CheckResource{}
 
 func NewCheckResource() resource.Resource {
 	return &CheckResource{}
 }
 
+func NewCheckListResource() list.ListResource {
+	return &CheckResource{}
+}
+
 // CheckResource defines the resource implementation.
 type CheckResource struct {
-	client     influxdb2.Client
-	org        string
-	serverURL  string
-	authToken  string
-	httpClient *http.Client
+	client              influxdb2.Client
+	api                 *apiclient.Client
+	batch               *apiclient.Batch
+	org                 string
+	url                 string
+	orgs                *common.OrgCache
+	readOnly            bool
+	ignoreNormalization bool
+	adoptExisting       bool
+	unconfigured        diag.Diagnostics
 }
 
 // CheckResourceModel describes the resource data model.
@@ -40,6 +64,7 @@ type CheckResourceModel struct {
 	ID                    types.String     `tfsdk:"id"`
 	Name                  types.String     `tfsdk:"name"`
 	Org                   types.String     `tfsdk:"org"`
+	OrgID                 types.String     `tfsdk:"org_id"`
 	Description           types.String     `tfsdk:"description"`
 	Query                 types.String     `tfsdk:"query"`
 	Status                types.String     `tfsdk:"status"`
@@ -50,6 +75,12 @@ type CheckResourceModel struct {
 	Thresholds            []ThresholdModel `tfsdk:"thresholds"`
 	CreatedAt             types.String     `tfsdk:"created_at"`
 	UpdatedAt             types.String     `tfsdk:"updated_at"`
+	LatestCompleted       types.String     `tfsdk:"latest_completed"`
+	LastRunStatus         types.String     `tfsdk:"last_run_status"`
+	LastRunError          types.String     `tfsdk:"last_run_error"`
+	SkipDestroy           types.Bool       `tfsdk:"skip_destroy"`
+	IgnoreNormalization   types.Bool       `tfsdk:"ignore_normalization"`
+	Timeouts              timeouts.Value   `tfsdk:"timeouts"`
 }
 
 type ThresholdModel struct {
@@ -59,37 +90,8 @@ type ThresholdModel struct {
 	AllValues types.Bool    `tfsdk:"all_values"`
 }
 
-// CheckAPI represents the structure used for InfluxDB Check API calls
-type CheckAPI struct {
-	ID                    *string          `json:"id,omitempty"`
-	Name                  string           `json:"name"`
-	OrgID                 string           `json:"orgID"`
-	Description           *string          `json:"description,omitempty"`
-	Query                 CheckQuery       `json:"query"`
-	Status                string           `json:"status"`
-	Every                 string           `json:"every"`
-	Offset                string           `json:"offset"`
-	StatusMessageTemplate *string          `json:"statusMessageTemplate,omitempty"`
-	Thresholds            []CheckThreshold `json:"thresholds"`
-	Type                  string           `json:"type"`
-	CreatedAt             *string          `json:"createdAt,omitempty"`
-	UpdatedAt             *string          `json:"updatedAt,omitempty"`
-}
-
-type CheckQuery struct {
-	Text string `json:"text"`
-}
-
-type CheckThreshold struct {
-	AllValues *bool   `json:"allValues,omitempty"`
-	Level     string  `json:"level"`
-	Value     float64 `json:"value"`
-	Type      string  `json:"type"`
-}
-
-type CheckListResponse struct {
-	Checks []CheckAPI `json:"checks"`
-}
+// defaultStatusMessageTemplate is the template InfluxDB uses for checks created in the UI
+const defaultStatusMessageTemplate = "Check: ${ r._check_name } is: ${ r._level }"
 
 func (r *CheckResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_check"
@@ -98,11 +100,16 @@ func (r *CheckResource) Metadata(ctx context.Context, req resource.MetadataReque
 func (r *CheckResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "InfluxDB check resource for monitoring and alerting",
+		// Version 1 stores thresholds as a set instead of a list
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Check ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -111,7 +118,18 @@ func (r *CheckResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"org": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default. Moving the check to another organization forces a new check to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Organization ID. Can be used instead of `org` to skip the organization name lookup, e.g. with tokens that cannot read organizations. Takes precedence over `org` when both are set, e.g. in configuration generated on import.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
@@ -122,42 +140,98 @@ func (r *CheckResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Flux query to execute for the check",
 			},
 			"status": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Check status (active or inactive).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(domain.TaskStatusTypeActive)),
+				MarkdownDescription: "Check status (active or inactive). Defaults to `active`.",
+				Validators: []validator.String{
+					validators.Status(),
+				},
 			},
 			"every": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Duration between check executions (e.g., '1m', '5m', '1h')",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
 			},
 			"offset": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Optional offset for check execution timing. Defaults to '0s'.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0s"),
+				MarkdownDescription: "Offset for check execution timing. Defaults to `0s`.",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
 			},
 			"status_message_template": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Template for status messages",
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultStatusMessageTemplate),
+				MarkdownDescription: "Template for status messages. Defaults to `" + defaultStatusMessageTemplate + "`.",
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Check type ('threshold' or 'deadman').",
+				MarkdownDescription: "Check type ('threshold' or 'deadman'). Changing the type forces a new check to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(domain.ThresholdCheckTypeThreshold), string(domain.DeadmanCheckTypeDeadman)),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Check creation timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"updated_at": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Check last update timestamp",
 			},
+			"latest_completed": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the latest scheduled and completed check run",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_run_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the latest check run (success, failed or canceled)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_run_error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Error message of the latest check run, if it failed",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ignore_normalization": ignoreNormalizationAttribute(),
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only remove the check from the Terraform state on destroy and keep it in InfluxDB. Only takes effect once applied, before the destroy. Defaults to `false`.",
+			},
 		},
 		Blocks: map[string]schema.Block{
-			"thresholds": schema.ListNestedBlock{
-				MarkdownDescription: "Threshold definitions for the check",
+			"timeouts": timeouts.BlockAll(ctx),
+			"thresholds": schema.SetNestedBlock{
+				MarkdownDescription: "Threshold definitions for the check. Thresholds are unordered, so reordering them does not produce a diff.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"type": schema.StringAttribute{
 							Required:            true,
-							MarkdownDescription: "Threshold comparison type (greater, lesser, equal, etc.)",
+							MarkdownDescription: "Threshold comparison type (greater or lesser)",
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(domain.GreaterThresholdTypeGreater), string(domain.LesserThresholdTypeLesser)),
+							},
 						},
 						"value": schema.Float64Attribute{
 							Required:            true,
@@ -166,10 +240,14 @@ func (r *CheckResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						"level": schema.StringAttribute{
 							Required:            true,
 							MarkdownDescription: "Alert level (CRIT, WARN, INFO, OK)",
+							Validators: []validator.String{
+								validators.CheckLevel(),
+							},
 						},
 						"all_values": schema.BoolAttribute{
 							Optional:            true,
 							Computed:            true,
+							Default:             booldefault.StaticBool(false),
 							MarkdownDescription: "Whether to apply threshold to all values. Defaults to false.",
 						},
 					},
@@ -179,6 +257,42 @@ func (r *CheckResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *CheckResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Thresholds were a list, which decodes into a set as-is
+		0: compatibleStateUpgrader(),
+	}
+}
+
+func (r *CheckResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema()
+}
+
+func (r *CheckResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.OffsetBeforeEvery(path.Root("every"), path.Root("offset")),
+	}
+}
+
+// ModifyPlan rejects new checks on servers without checks, ignores changes
+// InfluxDB normalizes away and replaces checks moved to another organization
+func (r *CheckResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireManagementAPI(ctx, r.api, r.unconfigured, req, resp, "influxdb_check")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignoreNormalizedChanges(ctx, req, resp, r.ignoreNormalization, map[string]normalizer{
+		"every":  normalizeDuration,
+		"offset": normalizeDuration,
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireReplaceOnOrgChange(ctx, req, resp, r.orgs, r.org, r.unconfigured)
+}
+
 func (r *CheckResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -189,106 +303,224 @@ func (r *CheckResource) Configure(ctx context.Context, req resource.ConfigureReq
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
 	r.client = providerData.Client
+	r.api = providerData.API
+	r.batch = providerData.Batch
 	r.org = providerData.Org
-
-	// Extract server URL and auth token for HTTP requests
-	r.serverURL = providerData.URL
-	r.authToken = providerData.Token
-	r.httpClient = &http.Client{}
+	r.url = providerData.URL
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+	r.ignoreNormalization = providerData.IgnoreNormalization
+	r.adoptExisting = providerData.AdoptExisting
 }
 
-// makeHTTPRequest makes an HTTP request to the InfluxDB API
-func (r *CheckResource) makeHTTPRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
+// formatTimestamp formats optional API timestamps the same way as the task resource
+func formatTimestamp(timestamp *time.Time) types.String {
+	if timestamp == nil {
+		return types.StringNull()
 	}
+	return types.StringValue(timestamp.Format("2006-01-02T15:04:05Z07:00"))
+}
 
-	url := fmt.Sprintf("%s%s", r.serverURL, endpoint)
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// setComputedFields sets computed fields from the check response
+func (r *CheckResource) setComputedFields(data *CheckResourceModel, check domain.CheckDiscriminator) {
+	var base domain.CheckBase
+	var every, offset, statusMessageTemplate *string
+	var thresholds []domain.Threshold
+
+	switch c := check.(type) {
+	case *domain.ThresholdCheck:
+		base, every, offset, statusMessageTemplate = c.CheckBase, c.Every, c.Offset, c.StatusMessageTemplate
+		data.Type = types.StringValue(string(c.Type))
+		if c.Thresholds != nil {
+			thresholds = *c.Thresholds
+		}
+	case *domain.DeadmanCheck:
+		base, every, offset, statusMessageTemplate = c.CheckBase, c.Every, c.Offset, c.StatusMessageTemplate
+		data.Type = types.StringValue(string(c.Type))
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Token %s", r.authToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	data.ID = types.StringPointerValue(base.Id)
+	data.Name = types.StringValue(base.Name)
+	data.Description = types.StringPointerValue(base.Description)
 
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+	if base.Query.Text != nil {
+		data.Query = types.StringValue(*base.Query.Text)
+	} else {
+		data.Query = types.StringNull()
+	}
+	if base.Status != nil {
+		data.Status = types.StringValue(string(*base.Status))
+	} else {
+		data.Status = types.StringValue(string(domain.TaskStatusTypeActive))
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	data.Every = types.StringPointerValue(every)
+	if offset != nil {
+		data.Offset = types.StringValue(*offset)
+	} else {
+		data.Offset = types.StringValue("0s")
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	// Always refresh the template so out of band changes, including removal, show up as drift
+	if statusMessageTemplate != nil {
+		data.StatusMessageTemplate = types.StringValue(*statusMessageTemplate)
+	} else {
+		data.StatusMessageTemplate = types.StringValue("")
 	}
 
-	return respBody, nil
-}
+	// Set thresholds from API response
+	data.Thresholds = make([]ThresholdModel, len(thresholds))
+	for i, threshold := range thresholds {
+		// decodeCheck only stores checkThreshold values. Range thresholds have no
+		// single value and are not configurable through this resource.
+		t, _ := threshold.(checkThreshold)
+		model := ThresholdModel{
+			Type:      types.StringValue(t.Type),
+			Value:     types.Float64PointerValue(t.Value),
+			Level:     types.StringNull(),
+			AllValues: types.BoolValue(t.AllValues),
+		}
+		if t.Level != "" {
+			model.Level = types.StringValue(t.Level)
+		}
 
-// setComputedFields sets computed fields from the check response
-func (r *CheckResource) setComputedFields(data *CheckResourceModel, check *CheckAPI) {
-	data.ID = types.StringValue(*check.ID)
-	data.Name = types.StringValue(check.Name)
+		data.Thresholds[i] = model
+	}
+
+	// Set timestamps
+	data.CreatedAt = formatTimestamp(base.CreatedAt)
+	data.UpdatedAt = formatTimestamp(base.UpdatedAt)
 
-	if check.Description != nil {
-		data.Description = types.StringValue(*check.Description)
+	// Set last run information
+	data.LatestCompleted = formatTimestamp(base.LatestCompleted)
+	if base.LastRunStatus != nil {
+		data.LastRunStatus = types.StringValue(string(*base.LastRunStatus))
 	} else {
-		data.Description = types.StringNull()
+		data.LastRunStatus = types.StringNull()
 	}
+	data.LastRunError = types.StringPointerValue(base.LastRunError)
+}
 
-	data.Query = types.StringValue(check.Query.Text)
-	data.Status = types.StringValue(check.Status)
-	data.Every = types.StringValue(check.Every)
-	data.Offset = types.StringValue(check.Offset)
-	data.Type = types.StringValue(check.Type)
-
-	if check.StatusMessageTemplate != nil && *check.StatusMessageTemplate != "" {
-		data.StatusMessageTemplate = types.StringValue(*check.StatusMessageTemplate)
+// buildThresholds converts the threshold models into their wire format
+func (r *CheckResource) buildThresholds(thresholds []ThresholdModel) ([]domain.Threshold, error) {
+	result := make([]domain.Threshold, len(thresholds))
+	for i, threshold := range thresholds {
+		switch threshold.Type.ValueString() {
+		case string(domain.GreaterThresholdTypeGreater), string(domain.LesserThresholdTypeLesser):
+			value := threshold.Value.ValueFloat64()
+			result[i] = checkThreshold{
+				Type:      threshold.Type.ValueString(),
+				Value:     &value,
+				Level:     threshold.Level.ValueString(),
+				AllValues: threshold.AllValues.ValueBool(),
+			}
+		default:
+			return nil, fmt.Errorf("unsupported threshold type %q, expected greater or lesser", threshold.Type.ValueString())
+		}
 	}
+	return result, nil
+}
 
-	// Set thresholds from API response
-	data.Thresholds = make([]ThresholdModel, len(check.Thresholds))
-	for i, threshold := range check.Thresholds {
-		allValues := false
-		if threshold.AllValues != nil {
-			allValues = *threshold.AllValues
+// buildCheck builds the generated check type matching the configured check type
+func (r *CheckResource) buildCheck(data *CheckResourceModel, orgID string) (domain.CheckDiscriminator, error) {
+	query := data.Query.ValueString()
+	status := domain.TaskStatusType(data.Status.ValueString())
+	every := data.Every.ValueString()
+	offset := data.Offset.ValueString()
+	statusMessageTemplate := data.StatusMessageTemplate.ValueString()
+
+	base := domain.CheckBase{
+		Name:        data.Name.ValueString(),
+		OrgID:       orgID,
+		Description: data.Description.ValueStringPointer(),
+		Query:       domain.DashboardQuery{Text: &query},
+		Status:      &status,
+	}
+
+	switch data.Type.ValueString() {
+	case string(domain.ThresholdCheckTypeThreshold):
+		thresholds, err := r.buildThresholds(data.Thresholds)
+		if err != nil {
+			return nil, err
 		}
-		data.Thresholds[i] = ThresholdModel{
-			Type:      types.StringValue(threshold.Type),
-			Value:     types.Float64Value(threshold.Value),
-			Level:     types.StringValue(threshold.Level),
-			AllValues: types.BoolValue(allValues),
+		return &domain.ThresholdCheck{
+			CheckBase:             base,
+			Every:                 &every,
+			Offset:                &offset,
+			StatusMessageTemplate: &statusMessageTemplate,
+			Thresholds:            &thresholds,
+			Type:                  domain.ThresholdCheckTypeThreshold,
+		}, nil
+	case string(domain.DeadmanCheckTypeDeadman):
+		return &domain.DeadmanCheck{
+			CheckBase:             base,
+			Every:                 &every,
+			Offset:                &offset,
+			StatusMessageTemplate: &statusMessageTemplate,
+			Type:                  domain.DeadmanCheckTypeDeadman,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported check type %q, expected threshold or deadman", data.Type.ValueString())
+	}
+}
+
+// thresholdsEqual compares two threshold sets ignoring their order
+func thresholdsEqual(a, b []ThresholdModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	key := func(t ThresholdModel) string {
+		return fmt.Sprintf("%s|%v|%s|%t", t.Type.ValueString(), t.Value.ValueFloat64(), t.Level.ValueString(), t.AllValues.ValueBool())
+	}
+	for _, threshold := range a {
+		counts[key(threshold)]++
+	}
+	for _, threshold := range b {
+		counts[key(threshold)]--
+		if counts[key(threshold)] < 0 {
+			return false
 		}
 	}
+	return true
+}
 
-	// Set timestamps
-	if check.CreatedAt != nil {
-		data.CreatedAt = types.StringValue(*check.CreatedAt)
-	} else {
-		data.CreatedAt = types.StringNull()
+// checkFieldsChanged reports whether the plan changes any attribute besides name,
+// description and status, which are the only ones PATCH updates
+func checkFieldsChanged(plan, state *CheckResourceModel) bool {
+	return !plan.Query.Equal(state.Query) ||
+		!plan.Every.Equal(state.Every) ||
+		!plan.Offset.Equal(state.Offset) ||
+		!plan.StatusMessageTemplate.Equal(state.StatusMessageTemplate) ||
+		!thresholdsEqual(plan.Thresholds, state.Thresholds)
+}
+
+// buildCheckPatch builds a PATCH body containing the name, description and status
+// if they differ between the prior state and the plan, leaving server managed
+// fields such as ownerID, taskID and createdAt untouched
+func buildCheckPatch(plan, state *CheckResourceModel) map[string]interface{} {
+	patch := map[string]interface{}{}
+
+	if !plan.Name.Equal(state.Name) {
+		patch["name"] = plan.Name.ValueString()
 	}
-	if check.UpdatedAt != nil {
-		data.UpdatedAt = types.StringValue(*check.UpdatedAt)
-	} else {
-		data.UpdatedAt = types.StringNull()
+	if !plan.Description.Equal(state.Description) {
+		// An empty description clears it when the attribute is removed from config
+		patch["description"] = plan.Description.ValueString()
+	}
+	if !plan.Status.Equal(state.Status) {
+		patch["status"] = plan.Status.ValueString()
 	}
+
+	return patch
 }
 
 func (r *CheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -301,81 +533,77 @@ func (r *CheckResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Use provider org if not specified
-	orgName := r.org
-	if !data.Org.IsNull() {
-		orgName = data.Org.ValueString()
+	createTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Resolve organization name to ID
-	orgsAPI := r.client.OrganizationsAPI()
-	org, err := orgsAPI.FindOrganizationByName(ctx, orgName)
-	if err != nil {
-		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to find organization '%s', got error: %s", orgName, err))
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_check", "Create", "")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Prepare check payload
-	checkPayload := CheckAPI{
-		Name:  data.Name.ValueString(),
-		OrgID: *org.Id,
-		Query: CheckQuery{
-			Text: data.Query.ValueString(),
-		},
-		Status:     data.Status.ValueString(),
-		Every:      data.Every.ValueString(),
-		Offset:     data.Offset.ValueString(),
-		Type:       data.Type.ValueString(),
-		Thresholds: make([]CheckThreshold, len(data.Thresholds)),
-	}
-
-	// Build thresholds array
-	for i, threshold := range data.Thresholds {
-		allValues := threshold.AllValues.ValueBool()
-		checkPayload.Thresholds[i] = CheckThreshold{
-			Type:      threshold.Type.ValueString(),
-			Value:     threshold.Value.ValueFloat64(),
-			Level:     threshold.Level.ValueString(),
-			AllValues: &allValues,
-		}
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "create", "influxdb_check") {
+		return
 	}
 
-	// Set optional fields
-	if !data.Description.IsNull() {
-		desc := data.Description.ValueString()
-		checkPayload.Description = &desc
-	}
-	if !data.Status.IsNull() {
-		checkPayload.Status = data.Status.ValueString()
-	}
-	if !data.Offset.IsNull() && data.Offset.ValueString() != "" {
-		checkPayload.Offset = data.Offset.ValueString()
+	// Resolve organization, IDs are used directly without a lookup
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
+	if err != nil {
+		resp.Diagnostics.AddError("Create - Client Error", err.Error())
+		return
 	}
-	// Type is required, so always use the configured value
-	checkPayload.Type = data.Type.ValueString()
-	if !data.StatusMessageTemplate.IsNull() {
-		template := data.StatusMessageTemplate.ValueString()
-		checkPayload.StatusMessageTemplate = &template
+
+	// Prepare check payload
+	checkPayload, err := r.buildCheck(&data, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Create - Invalid Configuration", err.Error())
+		return
 	}
 
 	// Create check via HTTP API
-	respBody, err := r.makeHTTPRequest(ctx, "POST", "/api/v2/checks", checkPayload)
+	respBody, err := r.api.Do(ctx, http.MethodPost, "checks", checkPayload)
+	if err != nil && r.adoptExisting && apiclient.IsConflict(err) {
+		// Take over the check of the same name, replacing it with the configuration
+		existingID, adoptErr := adoptableID(ctx, r.checkIDsByName, "check", orgID, data.Name.ValueString())
+		if adoptErr != nil {
+			resp.Diagnostics.AddError("Create - Adopt Error", fmt.Sprintf("Unable to adopt existing check: %s", adoptErr))
+			return
+		}
+		respBody, err = r.api.Do(ctx, http.MethodPut, "checks/"+existingID, checkPayload)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Create - HTTP Error", fmt.Sprintf("Unable to create check: %s", err))
+		addAPIError(&resp.Diagnostics, "Create - HTTP Error", fmt.Sprintf("Unable to create check: %s", err), err, checkErrorHints)
 		return
 	}
 
-	var createdCheck CheckAPI
-	if err := json.Unmarshal(respBody, &createdCheck); err != nil {
+	createdCheck, err := decodeCheck(respBody)
+	if err != nil {
 		resp.Diagnostics.AddError("Create - Parse Error", fmt.Sprintf("Unable to parse check response: %s", err))
 		return
 	}
 
 	// Set computed fields from API response
-	r.setComputedFields(&data, &createdCheck)
-	data.Org = types.StringValue(*org.Id)
+	r.setComputedFields(&data, createdCheck)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Report the provider default org, or the ID when the check was created by org_id
+		if r.org != "" && data.OrgID.IsUnknown() {
+			data.Org = types.StringValue(r.org)
+		} else {
+			data.Org = types.StringValue(orgID)
+		}
+	}
+	data.OrgID = types.StringValue(orgID)
 
 	// Save data into Terraform state
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	setDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(setDiags...)
 }
@@ -390,32 +618,56 @@ func (r *CheckResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get check by ID via HTTP API
-	endpoint := fmt.Sprintf("/api/v2/checks/%s", data.ID.ValueString())
-	respBody, err := r.makeHTTPRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Read - HTTP Error", fmt.Sprintf("Unable to read check: %s", err))
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var check CheckAPI
-	if err := json.Unmarshal(respBody, &check); err != nil {
-		resp.Diagnostics.AddError("Read - Parse Error", fmt.Sprintf("Unable to parse check response: %s", err))
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_check", "Read", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Resolve organization ID to name for consistency
-	orgsAPI := r.client.OrganizationsAPI()
-	org, err := orgsAPI.FindOrganizationByID(ctx, check.OrgID)
+	// Get check by ID via HTTP API, refreshes are served from one listing of the org's checks
+	respBody, err := r.batch.Get(ctx, "checks", data.OrgID.ValueString(), data.ID.ValueString())
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to find organization with ID '%s', got error: %s", check.OrgID, err))
+		resp.Diagnostics.AddError("Read - HTTP Error", fmt.Sprintf("Unable to read check: %s", err))
+		return
+	}
+
+	check, err := decodeCheck(respBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Read - Parse Error", fmt.Sprintf("Unable to parse check response: %s", err))
 		return
 	}
-	data.Org = types.StringValue(org.Name)
 
 	// Set computed fields
-	r.setComputedFields(&data, &check)
+	r.setComputedFields(&data, check)
+
+	// A check never moves between organizations, so the name is only resolved
+	// when it is not known yet, e.g. after import
+	orgID := checkOrgID(check)
+	data.OrgID = types.StringValue(orgID)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		data.Org = types.StringValue(orgNameOrID(ctx, r.orgs, orgID))
+	}
 
+	// skip_destroy only exists in Terraform, e.g. an import starts without it
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	readSetDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(readSetDiags...)
 }
@@ -431,6 +683,27 @@ func (r *CheckResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, timeoutDiags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_check", "Update", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "update", "influxdb_check") {
+		return
+	}
+
 	// Read current state to get the ID
 	stateDiags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(stateDiags...)
@@ -441,62 +714,60 @@ func (r *CheckResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Use the ID from state
 	data.ID = state.ID
 
-	// Prepare check payload for update
-	checkPayload := CheckAPI{
-		ID:   data.ID.ValueStringPointer(),
-		Name: data.Name.ValueString(),
-		Query: CheckQuery{
-			Text: data.Query.ValueString(),
-		},
-		Status:     data.Status.ValueString(),
-		Every:      data.Every.ValueString(),
-		Offset:     data.Offset.ValueString(),
-		Type:       data.Type.ValueString(),
-		Thresholds: make([]CheckThreshold, len(data.Thresholds)),
-	}
-
-	// Build thresholds array
-	for i, threshold := range data.Thresholds {
-		allValues := threshold.AllValues.ValueBool()
-		checkPayload.Thresholds[i] = CheckThreshold{
-			Type:      threshold.Type.ValueString(),
-			Value:     threshold.Value.ValueFloat64(),
-			Level:     threshold.Level.ValueString(),
-			AllValues: &allValues,
+	// PATCH only updates name, description and status and ignores everything else,
+	// so other changes replace the whole check. Pausing or resuming a check
+	// therefore sends nothing but the new status.
+	endpoint := fmt.Sprintf("checks/%s", data.ID.ValueString())
+	var respBody []byte
+	var err error
+	if checkFieldsChanged(&data, &state) {
+		checkPayload, buildErr := r.buildCheck(&data, state.OrgID.ValueString())
+		if buildErr != nil {
+			resp.Diagnostics.AddError("Update - Invalid Configuration", buildErr.Error())
+			return
 		}
-	}
 
-	// Set optional fields
-	if !data.Description.IsNull() {
-		desc := data.Description.ValueString()
-		checkPayload.Description = &desc
-	}
-	if !data.Offset.IsNull() && data.Offset.ValueString() != "" {
-		checkPayload.Offset = data.Offset.ValueString()
-	}
-	if !data.StatusMessageTemplate.IsNull() {
-		template := data.StatusMessageTemplate.ValueString()
-		checkPayload.StatusMessageTemplate = &template
-	}
+		// PUT replaces the check, so the fields InfluxDB manages are sent back as they are
+		currentBody, getErr := r.api.Do(ctx, http.MethodGet, endpoint, nil)
+		if getErr != nil {
+			resp.Diagnostics.AddError("Update - HTTP Error", fmt.Sprintf("Unable to read check before replacing it: %s", getErr))
+			return
+		}
+		currentCheck, decodeErr := decodeCheck(currentBody)
+		if decodeErr != nil {
+			resp.Diagnostics.AddError("Update - Parse Error", fmt.Sprintf("Unable to parse check response: %s", decodeErr))
+			return
+		}
+		copyServerManagedFields(checkPayload, currentCheck)
 
-	// Update check via HTTP API
-	endpoint := fmt.Sprintf("/api/v2/checks/%s", data.ID.ValueString())
-	respBody, err := r.makeHTTPRequest(ctx, "PATCH", endpoint, checkPayload)
+		respBody, err = r.api.Do(ctx, http.MethodPut, endpoint, checkPayload)
+	} else {
+		respBody, err = r.api.Do(ctx, http.MethodPatch, endpoint, buildCheckPatch(&data, &state))
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Update - HTTP Error", fmt.Sprintf("Unable to update check: %s", err))
+		addAPIError(&resp.Diagnostics, "Update - HTTP Error", fmt.Sprintf("Unable to update check: %s", err), err, checkErrorHints)
 		return
 	}
 
-	var updatedCheck CheckAPI
-	if err := json.Unmarshal(respBody, &updatedCheck); err != nil {
+	updatedCheck, err := decodeCheck(respBody)
+	if err != nil {
 		resp.Diagnostics.AddError("Update - Parse Error", fmt.Sprintf("Unable to parse check response: %s", err))
 		return
 	}
 
 	// Update data from API response
-	r.setComputedFields(&data, &updatedCheck)
-	data.Org = types.StringValue(updatedCheck.OrgID)
+	r.setComputedFields(&data, updatedCheck)
+	data.OrgID = types.StringValue(checkOrgID(updatedCheck))
+	if data.Org.IsUnknown() {
+		data.Org = state.Org
+	}
 
+	// Last run information only changes when the check runs, it is refreshed on Read
+	data.LatestCompleted = state.LatestCompleted
+	data.LastRunStatus = state.LastRunStatus
+	data.LastRunError = state.LastRunError
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	updateSetDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(updateSetDiags...)
 }
@@ -511,22 +782,71 @@ func (r *CheckResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Delete check via HTTP API
-	endpoint := fmt.Sprintf("/api/v2/checks/%s", data.ID.ValueString())
-	_, err := r.makeHTTPRequest(ctx, "DELETE", endpoint, nil)
+	// The check is kept in InfluxDB, Terraform drops it from the state
+	if data.SkipDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_check", "Delete", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "delete", "influxdb_check") {
+		return
+	}
+
+	// Delete check via the generated API client
+	err := r.client.APIClient().DeleteChecksID(ctx, &domain.DeleteChecksIDAllParams{
+		CheckID: data.ID.ValueString(),
+	})
 	if err != nil {
 		// Check if it's a 404 (not found) - this is okay for delete operations
-		if strings.Contains(err.Error(), "404") {
+		if apiclient.IsNotFound(err) {
 			// Resource already deleted, consider this success
 			return
 		}
-		resp.Diagnostics.AddError("Delete - HTTP Error", fmt.Sprintf("Unable to delete check: %s", err))
+		resp.Diagnostics.AddError("Delete - Client Error", fmt.Sprintf("Unable to delete check: %s", err))
 		return
 	}
 }
 
 func (r *CheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import using check ID
-	diags := resp.State.SetAttribute(ctx, path.Root("id"), req.ID)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Import using check ID or org/name
+	importByIDOrName(ctx, req, resp, r.orgs, r.org, r.url, "check", r.checkIDsByName)
+}
+
+// checkIDsByName returns the IDs of the checks with the given name in an organization
+func (r *CheckResource) checkIDsByName(ctx context.Context, orgID, name string) ([]string, error) {
+	return listIDsByName(ctx, r.api, "checks", orgID, name)
+}
+
+func (r *CheckResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listConfigSchema()
+}
+
+func (r *CheckResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.unconfigured.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(r.unconfigured)
+		return
+	}
+
+	listCollection(ctx, req, stream, r, r.api, r.orgs, r.org, r.url, "checks", nil)
 }
@@ -0,0 +1,267 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// thresholdCheckAttributes returns the attributes of a planned threshold check
+func thresholdCheckAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    "cpu",
+		"org_id":                  testOrgID,
+		"query":                   `from(bucket: "telegraf") |> range(start: -1m)`,
+		"status":                  "active",
+		"every":                   "1m",
+		"offset":                  "0s",
+		"status_message_template": "CPU is ${r._level}",
+		"type":                    "threshold",
+		"thresholds": []ThresholdModel{{
+			Type:      types.StringValue("greater"),
+			Value:     types.Float64Value(0.1),
+			Level:     types.StringValue("CRIT"),
+			AllValues: types.BoolValue(false),
+		}},
+	}
+}
+
+func TestCheckCreateRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRequests int
+		wantErr      bool
+	}{
+		// InfluxDB did not process the request, so it is sent again
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantRequests: 2},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantRequests: 2},
+		// The check may have been created, a second POST could fail with a conflict
+		{name: "bad gateway", status: http.StatusBadGateway, wantRequests: 1, wantErr: true},
+		{name: "internal error", status: http.StatusInternalServerError, wantRequests: 1, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := newMockAPI(t)
+			attempts := 0
+			api.handle(http.MethodPost, "checks", func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					writeAPIError(w, test.status, "unavailable", "try again later")
+					return
+				}
+				echoCreated("0000000000000001")(w, r)
+			})
+
+			r := NewCheckResource()
+			configure(t, r, api.providerData(3))
+			resp := create(t, r, thresholdCheckAttributes())
+
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("got diagnostics %v, want error: %t", resp.Diagnostics, test.wantErr)
+			}
+			requests := api.requestsTo(http.MethodPost, "checks")
+			if len(requests) != test.wantRequests {
+				t.Fatalf("got %d requests, want %d", len(requests), test.wantRequests)
+			}
+			// Retries send the complete check again
+			for _, request := range requests {
+				if request.JSON(t)["name"] != "cpu" {
+					t.Errorf("got body %s", request.Body)
+				}
+			}
+			if !test.wantErr && stateString(t, resp.State, "id").ValueString() != "0000000000000001" {
+				t.Errorf("got id %s", stateString(t, resp.State, "id"))
+			}
+		})
+	}
+}
+
+func TestCheckCreatePayload(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "checks", echoCreated("0000000000000001"))
+
+	r := NewCheckResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, thresholdCheckAttributes())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+
+	request := api.lastRequest(http.MethodPost, "checks")
+	if got := request.Header.Get("Authorization"); got != "Token "+testToken {
+		t.Errorf("got Authorization %q", got)
+	}
+	if got := request.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("got Content-Type %q", got)
+	}
+
+	body := request.JSON(t)
+	want := map[string]interface{}{
+		"name":                  "cpu",
+		"orgID":                 testOrgID,
+		"type":                  "threshold",
+		"status":                "active",
+		"every":                 "1m",
+		"offset":                "0s",
+		"statusMessageTemplate": "CPU is ${r._level}",
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("got %s %v, want %v", key, body[key], value)
+		}
+	}
+	if query, _ := body["query"].(map[string]interface{}); query["text"] != `from(bucket: "telegraf") |> range(start: -1m)` {
+		t.Errorf("got query %v", body["query"])
+	}
+
+	// Threshold values keep their float64 precision on the wire and in state
+	thresholds, _ := body["thresholds"].([]interface{})
+	if len(thresholds) != 1 {
+		t.Fatalf("got thresholds %v", body["thresholds"])
+	}
+	threshold := thresholds[0].(map[string]interface{})
+	if threshold["type"] != "greater" || threshold["value"] != 0.1 || threshold["level"] != "CRIT" || threshold["allValues"] != false {
+		t.Errorf("got threshold %v", threshold)
+	}
+
+	var state []ThresholdModel
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("thresholds"), &state); diags.HasError() {
+		t.Fatalf("unable to get thresholds: %v", diags)
+	}
+	if len(state) != 1 || state[0].Value.ValueFloat64() != 0.1 {
+		t.Errorf("got thresholds %v in state", state)
+	}
+}
+
+func TestCheckUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    func(attributes map[string]interface{})
+		wantPatch map[string]interface{}
+	}{
+		{
+			name:      "status",
+			change:    func(attributes map[string]interface{}) { attributes["status"] = "inactive" },
+			wantPatch: map[string]interface{}{"status": "inactive"},
+		},
+		{
+			name: "name and description",
+			change: func(attributes map[string]interface{}) {
+				attributes["name"] = "cpu usage"
+				attributes["description"] = "CPU usage"
+			},
+			wantPatch: map[string]interface{}{"name": "cpu usage", "description": "CPU usage"},
+		},
+		// PATCH ignores everything else, so the whole check is replaced
+		{
+			name:   "query",
+			change: func(attributes map[string]interface{}) { attributes["query"] = `from(bucket: "system")` },
+		},
+		{
+			name:   "every",
+			change: func(attributes map[string]interface{}) { attributes["every"] = "5m" },
+		},
+		{
+			name:   "status message template",
+			change: func(attributes map[string]interface{}) { attributes["status_message_template"] = "changed" },
+		},
+		{
+			name: "threshold value",
+			change: func(attributes map[string]interface{}) {
+				thresholds := attributes["thresholds"].([]ThresholdModel)
+				thresholds[0].Value = types.Float64Value(0.25)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.handle(http.MethodPut, "checks/0000000000000001", echo(http.StatusOK, "0000000000000001"))
+			api.respond(http.MethodGet, "checks/0000000000000001", http.StatusOK, `{
+				"id": "0000000000000001", "orgID": "`+testOrgID+`", "type": "threshold", "name": "cpu",
+				"ownerID": "00000000000000ff", "taskID": "00000000000000ee", "query": {"text": "q"}
+			}`)
+			api.handle(http.MethodPatch, "checks/0000000000000001", func(w http.ResponseWriter, r *http.Request) {
+				// PATCH answers with the whole check
+				check := map[string]interface{}{
+					"id": "0000000000000001", "orgID": testOrgID, "type": "threshold",
+					"name": "cpu", "status": "active", "query": map[string]string{"text": "q"},
+				}
+				var patch map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&patch)
+				for key, value := range patch {
+					check[key] = value
+				}
+				_ = json.NewEncoder(w).Encode(check)
+			})
+
+			prior := thresholdCheckAttributes()
+			prior["id"] = "0000000000000001"
+			planned := thresholdCheckAttributes()
+			planned["id"] = "0000000000000001"
+			test.change(planned)
+
+			r := NewCheckResource()
+			configure(t, r, api.providerData(0))
+			resp := update(t, r, prior, planned)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Update failed: %v", resp.Diagnostics)
+			}
+
+			patches := api.requestsTo(http.MethodPatch, "checks/0000000000000001")
+			puts := api.requestsTo(http.MethodPut, "checks/0000000000000001")
+			if test.wantPatch != nil {
+				if len(patches) != 1 || len(puts) != 0 {
+					t.Fatalf("got %d PATCH and %d PUT requests, want one PATCH", len(patches), len(puts))
+				}
+				if body := patches[0].JSON(t); !reflect.DeepEqual(body, test.wantPatch) {
+					t.Errorf("got PATCH body %v, want %v", body, test.wantPatch)
+				}
+				return
+			}
+
+			if len(puts) != 1 || len(patches) != 0 {
+				t.Fatalf("got %d PATCH and %d PUT requests, want one PUT", len(patches), len(puts))
+			}
+			// The replaced check must contain the type specific fields
+			body := puts[0].JSON(t)
+			for _, key := range []string{"name", "orgID", "type", "query", "every", "offset", "statusMessageTemplate", "thresholds"} {
+				if _, ok := body[key]; !ok {
+					t.Errorf("PUT body is missing %s: %s", key, puts[0].Body)
+				}
+			}
+			// and keep the owner and task of the current check
+			if body["ownerID"] != "00000000000000ff" || body["taskID"] != "00000000000000ee" {
+				t.Errorf("PUT body dropped server managed fields: %s", puts[0].Body)
+			}
+		})
+	}
+}
+
+func TestCheckDelete(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodDelete, "checks/0000000000000001", http.StatusNoContent, "")
+
+	r := NewCheckResource()
+	configure(t, r, api.providerData(0))
+
+	state := newState(t, r, map[string]interface{}{"id": "0000000000000001", "org_id": testOrgID})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %v", resp.Diagnostics)
+	}
+	if len(api.requestsTo(http.MethodDelete, "checks/0000000000000001")) != 1 {
+		t.Error("the check was not deleted")
+	}
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// identityModel is the identity shared by all resources, the InfluxDB server
+// together with the ID of the resource on it
+type identityModel struct {
+	URL types.String `tfsdk:"url"`
+	ID  types.String `tfsdk:"id"`
+}
+
+// identitySchema returns the identity schema shared by all resources
+func identitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"url": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "URL of the InfluxDB server. Must match the provider URL when set on import.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the resource",
+			},
+		},
+	}
+}
+
+// setIdentity stores the identity of a resource. Identity is nil when Terraform
+// does not support resource identity.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, serverURL string, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, identityModel{
+		URL: types.StringValue(serverURL),
+		ID:  id,
+	})
+}
+
+// importByIdentity imports a resource from an identity, rejecting identities of
+// another InfluxDB server
+func importByIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, serverURL string) {
+	var identity identityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identity.URL.IsNull() && strings.TrimSuffix(identity.URL.ValueString(), "/") != strings.TrimSuffix(serverURL, "/") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Import - Server Mismatch",
+			fmt.Sprintf("The identity belongs to the InfluxDB server %s, but the provider is configured for %s.", identity.URL.ValueString(), serverURL),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// nameLookup returns the IDs of the resources with the given name in an organization
+type nameLookup func(ctx context.Context, orgID, name string) ([]string, error)
+
+// importByIDOrName imports a resource by identity, ID or `org/name`, where org is
+// an organization name or ID. The org attribute keeps the given form, the following
+// Read fills in everything else.
+func importByIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, orgs *common.OrgCache, defaultOrg, serverURL, kind string, lookup nameLookup) {
+	if req.ID == "" && req.Identity != nil {
+		importByIdentity(ctx, req, resp, serverURL)
+		return
+	}
+
+	org, name, found := strings.Cut(req.ID, "/")
+	if !found {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if org == "" || name == "" {
+		resp.Diagnostics.AddError("Import - Invalid Import ID", fmt.Sprintf("Expected a %s ID or org/name, got: %q", kind, req.ID))
+		return
+	}
+
+	orgID, err := resolveOrgID(ctx, orgs, types.StringNull(), types.StringValue(org), defaultOrg)
+	if err != nil {
+		resp.Diagnostics.AddError("Import - Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
+		return
+	}
+
+	ids, err := lookup(ctx, orgID, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Import - Lookup Error", fmt.Sprintf("Unable to look up %s %q: %s", kind, name, err))
+		return
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError("Import - Not Found", fmt.Sprintf("No %s named %q found in organization %s", kind, name, org))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError("Import - Ambiguous Name", fmt.Sprintf("Found %d %ss named %q in organization %s, import by ID instead", len(ids), kind, name, org))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org"), org)...)
+}
+
+// listIDsByName pages through a collection of the API, e.g. "checks", and returns
+// the IDs of its members with the given name
+func listIDsByName(ctx context.Context, api *apiclient.Client, collection, orgID, name string) ([]string, error) {
+	items, err := api.ListAll(ctx, collection, url.Values{"orgID": {orgID}})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, item := range items {
+		var member struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(item, &member); err != nil {
+			return nil, fmt.Errorf("unable to parse %s response: %w", collection, err)
+		}
+
+		if member.Name == name {
+			ids = append(ids, member.ID)
+		}
+	}
+
+	return ids, nil
+}
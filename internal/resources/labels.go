@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// labelIDs returns the IDs of labels returned by a labels sub-API
+func labelIDs(response *domain.LabelsResponse) []string {
+	if response == nil || response.Labels == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(*response.Labels))
+	for _, label := range *response.Labels {
+		if label.Id != nil {
+			ids = append(ids, *label.Id)
+		}
+	}
+	return ids
+}
+
+// diffLabels returns the label IDs to attach and detach to get from current to desired
+func diffLabels(current, desired []string) (add, remove []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		desiredSet[id] = true
+		if !currentSet[id] {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !desiredSet[id] {
+			remove = append(remove, id)
+		}
+	}
+	return add, remove
+}
+
+// labelSetValue converts label IDs into the labels attribute value. No labels map
+// to null so an unset attribute does not show a diff.
+func labelSetValue(ctx context.Context, ids []string) (types.Set, diag.Diagnostics) {
+	if len(ids) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, ids)
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// listConfigModel is the configuration of the list blocks of all resources
+type listConfigModel struct {
+	Org types.String `tfsdk:"org"`
+}
+
+// listMember holds the fields of a listed API object needed to select and name it
+type listMember struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// listConfigSchema returns the list block schema shared by all resources
+func listConfigSchema() listschema.Schema {
+	return listschema.Schema{
+		Attributes: map[string]listschema.Attribute{
+			"org": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Organization name or ID to list. If not provided, uses the provider default.",
+			},
+		},
+	}
+}
+
+// listCollection streams the members of an API collection, e.g. "checks", as list
+// results. With include set, only matching members are listed. When Terraform asks
+// for the resource data, e.g. to generate configuration, each member is read with
+// the Read of the resource, so the results match an import.
+func listCollection(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream, r resource.Resource, api *apiclient.Client, orgs *common.OrgCache, defaultOrg, serverURL, collection string, include func(listMember) bool) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	orgID, err := resolveOrgID(ctx, orgs, types.StringNull(), config.Org, defaultOrg)
+	if err != nil {
+		diags.AddError("List - Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items, err := api.ListAll(ctx, collection, url.Values{"orgID": {orgID}})
+	if err != nil {
+		diags.AddError("List - Client Error", fmt.Sprintf("Unable to list %s: %s", collection, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	members := make([]listMember, 0, len(items))
+	for _, item := range items {
+		var member listMember
+		if err := json.Unmarshal(item, &member); err != nil {
+			diags.AddError("List - Deserialization Error", fmt.Sprintf("Unable to parse %s response: %s", collection, err))
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		if include == nil || include(member) {
+			members = append(members, member)
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, member := range members {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = member.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, identityModel{
+				URL: types.StringValue(serverURL),
+				ID:  types.StringValue(member.ID),
+			})...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				readListResult(ctx, r, &result, member.ID)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readListResult fills the resource data of a list result by running the Read of
+// the resource against a state holding only the ID, the same as after an import
+func readListResult(ctx context.Context, r resource.Resource, result *list.ListResult, id string) {
+	state := tfsdk.State{
+		Schema: result.Resource.Schema,
+		Raw:    result.Resource.Raw,
+	}
+	result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), id)...)
+	if result.Diagnostics.HasError() {
+		return
+	}
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	result.Diagnostics.Append(readResp.Diagnostics...)
+	result.Resource.Raw = readResp.State.Raw
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
+)
+
+// normalizer maps values InfluxDB treats as equal to the same string
+type normalizer func(string) string
+
+// normalizeDuration formats durations canonically, e.g. "60m" as "1h"
+func normalizeDuration(value string) string {
+	duration, err := validators.ParseDuration(value)
+	if err != nil {
+		return value
+	}
+	return validators.FormatDuration(duration)
+}
+
+// ignoreNormalizationAttribute returns the per-resource override of the provider's
+// ignore_normalization setting
+func ignoreNormalizationAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether differences InfluxDB normalizes away, such as whitespace in Flux or `60m` instead of `1h`, are ignored instead of planned as changes. Defaults to the provider's `ignore_normalization`.",
+	}
+}
+
+// normalizationIgnored resolves the per-resource override against the provider setting
+func normalizationIgnored(override types.Bool, providerDefault bool) bool {
+	if override.IsNull() || override.IsUnknown() {
+		return providerDefault
+	}
+	return override.ValueBool()
+}
+
+// ignoreNormalizedChanges plans the state value of every attribute whose configured
+// value only differs from the state in its normalized form, so these differences
+// are not shown as changes
+func ignoreNormalizedChanges(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, providerDefault bool, normalizers map[string]normalizer) {
+	// Nothing to compare on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var override types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ignore_normalization"), &override)...)
+	if resp.Diagnostics.HasError() || !normalizationIgnored(override, providerDefault) {
+		return
+	}
+
+	for name, normalize := range normalizers {
+		var planned, prior types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if planned.IsNull() || planned.IsUnknown() || prior.IsNull() || planned.Equal(prior) {
+			continue
+		}
+
+		if normalize(planned.ValueString()) == normalize(prior.ValueString()) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), prior)...)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+// removeIfNotFound removes a resource which was deleted outside of Terraform from
+// state, so the next plan recreates it instead of failing. It reports whether the
+// error was handled.
+func removeIfNotFound(ctx context.Context, err error, resp *resource.ReadResponse) bool {
+	if !apiclient.IsNotFound(err) {
+		return false
+	}
+
+	resp.State.RemoveResource(ctx)
+	return true
+}
@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestReadRemovesMissingResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		resource   func() resource.Resource
+		collection string
+	}{
+		{name: "bucket", resource: NewBucketResource, collection: "buckets"},
+		{name: "task", resource: NewTaskResource, collection: "tasks"},
+		{name: "check", resource: NewCheckResource, collection: "checks"},
+		{name: "notification endpoint", resource: NewNotificationEndpointResource, collection: "notificationEndpoints"},
+		{name: "notification rule", resource: NewNotificationRuleResource, collection: "notificationRules"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.respond(http.MethodGet, test.collection, http.StatusOK, `{"`+test.collection+`":[]}`)
+
+			r := test.resource()
+			configure(t, r, api.providerData(0))
+			resp := read(t, r, map[string]interface{}{
+				"id":     "0000000000000001",
+				"org_id": testOrgID,
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read failed: %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Error("a resource deleted outside of Terraform must be removed from state")
+			}
+			if len(api.requestsTo(http.MethodGet, test.collection+"/0000000000000001")) != 1 {
+				t.Errorf("expected one request for the missing %s", test.name)
+			}
+		})
+	}
+}
+
+func TestReadKeepsResourcesOnOtherErrors(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "checks", http.StatusOK, `{"checks":[]}`)
+	api.handle(http.MethodGet, "checks/0000000000000001", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusForbidden, "forbidden", "insufficient permissions")
+	})
+
+	r := NewCheckResource()
+	configure(t, r, api.providerData(0))
+	resp := read(t, r, map[string]interface{}{
+		"id":     "0000000000000001",
+		"org_id": testOrgID,
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Read must fail instead of dropping the check when it cannot be read")
+	}
+	if resp.State.Raw.IsNull() {
+		t.Error("the check must stay in state")
+	}
+}
@@ -1,55 +1,102 @@
 package resources
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NotificationEndpointResource{}
 var _ resource.ResourceWithImportState = &NotificationEndpointResource{}
+var _ resource.ResourceWithIdentity = &NotificationEndpointResource{}
+var _ list.ListResourceWithConfigure = &NotificationEndpointResource{}
+var _ resource.ResourceWithConfigValidators = &NotificationEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &NotificationEndpointResource{}
 
 func NewNotificationEndpointResource() resource.Resource {
 	return &NotificationEndpointResource{}
 }
 
+func NewNotificationEndpointListResource() list.ListResource {
+	return &NotificationEndpointResource{}
+}
+
 // NotificationEndpointResource defines the resource implementation.
 type NotificationEndpointResource struct {
-	client     influxdb2.Client
-	org        string
-	serverURL  string
-	authToken  string
-	httpClient *http.Client
+	client influxdb2.Client
+	org    string
+	url    string
+	orgs   *common.OrgCache
+	api    *apiclient.Client
+	batch  *apiclient.Batch
+	// httpClient sends test notifications directly from the provider to the destination
+	httpClient   *http.Client
+	readOnly     bool
+	unconfigured diag.Diagnostics
 }
 
 // NotificationEndpointResourceModel describes the resource data model.
 type NotificationEndpointResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Org             types.String `tfsdk:"org"`
-	Description     types.String `tfsdk:"description"`
-	Status          types.String `tfsdk:"status"`
-	Type            types.String `tfsdk:"type"`
-	URL             types.String `tfsdk:"url"`
-	Token           types.String `tfsdk:"token"`
-	Username        types.String `tfsdk:"username"`
-	Password        types.String `tfsdk:"password"`
-	Method          types.String `tfsdk:"method"`
-	AuthMethod      types.String `tfsdk:"auth_method"`
-	Headers         types.Map    `tfsdk:"headers"`
-	ContentTemplate types.String `tfsdk:"content_template"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Org               types.String   `tfsdk:"org"`
+	OrgID             types.String   `tfsdk:"org_id"`
+	Description       types.String   `tfsdk:"description"`
+	Status            types.String   `tfsdk:"status"`
+	Type              types.String   `tfsdk:"type"`
+	URL               types.String   `tfsdk:"url"`
+	Token             types.String   `tfsdk:"token"`
+	Username          types.String   `tfsdk:"username"`
+	Password          types.String   `tfsdk:"password"`
+	Method            types.String   `tfsdk:"method"`
+	AuthMethod        types.String   `tfsdk:"auth_method"`
+	Headers           types.Map      `tfsdk:"headers"`
+	ContentTemplate   types.String   `tfsdk:"content_template"`
+	RoutingKey        types.String   `tfsdk:"routing_key"`
+	ClientURL         types.String   `tfsdk:"client_url"`
+	Labels            types.Set      `tfsdk:"labels"`
+	TokenSecretKey    types.String   `tfsdk:"token_secret_key"`
+	PasswordSecretKey types.String   `tfsdk:"password_secret_key"`
+	TokenWO           types.String   `tfsdk:"token_wo"`
+	TokenWOVersion    types.Int64    `tfsdk:"token_wo_version"`
+	PasswordWO        types.String   `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64    `tfsdk:"password_wo_version"`
+	CreatedAt         types.String   `tfsdk:"created_at"`
+	UpdatedAt         types.String   `tfsdk:"updated_at"`
+	VerifyOnCreate    types.Bool     `tfsdk:"verify_on_create"`
+	SkipDestroy       types.Bool     `tfsdk:"skip_destroy"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *NotificationEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,6 +111,9 @@ func (r *NotificationEndpointResource) Schema(ctx context.Context, req resource.
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Notification endpoint ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -72,28 +122,70 @@ func (r *NotificationEndpointResource) Schema(ctx context.Context, req resource.
 			"org": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default. Moving the endpoint to another organization forces a new endpoint to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Organization ID. Can be used instead of `org`; always exported so notification rules can reference the endpoint organization. Takes precedence over `org` when both are set, e.g. in configuration generated on import.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Notification endpoint description",
 			},
 			"status": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Status of the notification endpoint (active, inactive)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("active"),
+				MarkdownDescription: "Status of the notification endpoint (active, inactive). Defaults to `active`.",
+				Validators: []validator.String{
+					validators.Status(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Type of notification endpoint (http, slack, pagerduty, etc.)",
+				MarkdownDescription: "Type of notification endpoint (http, slack, pagerduty). Changing the type forces a new endpoint to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("http", "slack", "pagerduty"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"url": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "URL of the notification endpoint",
+				Optional:            true,
+				MarkdownDescription: "URL of the notification endpoint. Required for http endpoints, optional for slack endpoints using a token.",
 			},
 			"token": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Authentication token (for endpoints that require it)",
+				MarkdownDescription: "Authentication token (for endpoints that require it, e.g. the Slack app token)",
+			},
+			"token_secret_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Store the token in the organization secret with this key and only reference it from the endpoint. The secret is kept when the endpoint is destroyed.",
+				Validators: []validator.String{
+					validators.AlsoRequiresOneOf(path.MatchRoot("token"), path.MatchRoot("token_wo")),
+				},
+			},
+			"token_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Write-only alternative to `token` which is sent to InfluxDB but never stored in state. Requires Terraform 1.11 or later. Change `token_wo_version` to send a new value.",
+			},
+			"token_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `token_wo`. Terraform cannot detect changes of write-only values, so changing the version triggers an update of the token.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("token_wo")),
+				},
 			},
 			"username": schema.StringAttribute{
 				Optional:            true,
@@ -104,25 +196,125 @@ func (r *NotificationEndpointResource) Schema(ctx context.Context, req resource.
 				Sensitive:           true,
 				MarkdownDescription: "Password for basic authentication",
 			},
+			"password_secret_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Store the password in the organization secret with this key and only reference it from the endpoint. The secret is kept when the endpoint is destroyed.",
+				Validators: []validator.String{
+					validators.AlsoRequiresOneOf(path.MatchRoot("password"), path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Write-only alternative to `password` which is sent to InfluxDB but never stored in state. Requires Terraform 1.11 or later. Change `password_wo_version` to send a new value.",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `password_wo`. Terraform cannot detect changes of write-only values, so changing the version triggers an update of the password.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
 			"method": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "HTTP method to use (POST, PUT, etc.)",
+				Optional:            true,
+				MarkdownDescription: "HTTP method to use (POST, GET, PUT). Required for http endpoints.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "GET", "PUT"),
+				},
 			},
 			"auth_method": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Authentication method (none, basic, bearer)",
+				Optional:            true,
+				MarkdownDescription: "Authentication method (none, basic, bearer). Required for http endpoints.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "basic", "bearer"),
+				},
 			},
 			"headers": schema.MapAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Additional headers to send with the request",
+				MarkdownDescription: "Additional headers to send with the request (http endpoints only)",
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
 			},
 			"content_template": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Template for the notification message content",
 			},
+			"routing_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PagerDuty integration routing key. Required for pagerduty endpoints.",
+			},
+			"client_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL linked from PagerDuty incidents back to the client, e.g. an InfluxDB dashboard (pagerduty endpoints only)",
+			},
+			"labels": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the labels attached to the notification endpoint",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"verify_on_create": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Send a test notification through the endpoint after creating it and fail the apply if delivery fails. PagerDuty test incidents are resolved right away. Defaults to `false`.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Notification endpoint creation timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Notification endpoint last update timestamp",
+			},
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only remove the notification endpoint from the Terraform state on destroy and keep it in InfluxDB. Only takes effect once applied, before the destroy. Defaults to `false`.",
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *NotificationEndpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema()
+}
+
+func (r *NotificationEndpointResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.RequiredWhen(path.Root("type"), "http", path.Root("url"), path.Root("method"), path.Root("auth_method")),
+		resourcevalidator.Conflicting(path.MatchRoot("token"), path.MatchRoot("token_wo")),
+		resourcevalidator.Conflicting(path.MatchRoot("password"), path.MatchRoot("password_wo")),
+		validators.AtLeastOneOfWhen(path.Root("type"), "slack", path.Root("url"), path.Root("token"), path.Root("token_wo")),
+		validators.RequiredWhen(path.Root("type"), "pagerduty", path.Root("routing_key")),
+		validators.RequiredWhen(path.Root("auth_method"), "basic", path.Root("username")),
+		validators.AtLeastOneOfWhen(path.Root("auth_method"), "basic", path.Root("password"), path.Root("password_wo")),
+		validators.AtLeastOneOfWhen(path.Root("auth_method"), "bearer", path.Root("token"), path.Root("token_wo")),
+	}
+}
+
+// ModifyPlan rejects new endpoints on servers without notification support and
+// replaces endpoints moved to another organization
+func (r *NotificationEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireManagementAPI(ctx, r.api, r.unconfigured, req, resp, "influxdb_notification_endpoint")
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	requireReplaceOnOrgChange(ctx, req, resp, r.orgs, r.org, r.unconfigured)
 }
 
 func (r *NotificationEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -143,20 +335,37 @@ func (r *NotificationEndpointResource) Configure(ctx context.Context, req resour
 
 	r.client = providerData.Client
 	r.org = providerData.Org
-	r.serverURL = providerData.URL
-	r.authToken = providerData.Token
-	r.httpClient = &http.Client{}
+	r.url = providerData.URL
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+	r.api = providerData.API
+	r.batch = providerData.Batch
+	r.httpClient = providerData.HTTPClient
+}
+
+// supportedEndpointTypes are the endpoint types this resource can manage
+var supportedEndpointTypes = map[string]struct{}{
+	"http":      {},
+	"slack":     {},
+	"pagerduty": {},
 }
 
 type NotificationEndpointRequest struct {
 	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
 	Type            string            `json:"type"`
-	URL             string            `json:"url"`
+	URL             string            `json:"url,omitempty"`
+	Token           string            `json:"token,omitempty"`
+	Username        string            `json:"username,omitempty"`
+	Password        string            `json:"password,omitempty"`
 	Status          string            `json:"status"`
-	Method          string            `json:"method"`
-	AuthMethod      string            `json:"authMethod"`
+	Method          string            `json:"method,omitempty"`
+	AuthMethod      string            `json:"authMethod,omitempty"`
 	Headers         map[string]string `json:"headers,omitempty"`
 	ContentTemplate *string           `json:"contentTemplate,omitempty"`
+	RoutingKey      string            `json:"routingKey,omitempty"`
+	ClientURL       string            `json:"clientURL,omitempty"`
 	OrgID           string            `json:"orgID"`
 }
 
@@ -174,48 +383,59 @@ type NotificationEndpointResponse struct {
 	AuthMethod      string            `json:"authMethod"`
 	Headers         map[string]string `json:"headers"`
 	ContentTemplate *string           `json:"contentTemplate"`
+	RoutingKey      *string           `json:"routingKey"`
+	ClientURL       string            `json:"clientURL"`
 	OrgID           string            `json:"orgID"`
+	CreatedAt       *time.Time        `json:"createdAt"`
+	UpdatedAt       *time.Time        `json:"updatedAt"`
 }
 
-func (r *NotificationEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data NotificationEndpointResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	org := r.org
-	if !data.Org.IsNull() {
-		org = data.Org.ValueString()
-	}
-
-	// Get org ID
-	orgAPI := r.client.OrganizationsAPI()
-	orgObj, err := orgAPI.FindOrganizationByName(ctx, org)
-	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] Client Error", fmt.Sprintf("Unable to find organization %s, got error: %s", org, err))
-		return
-	}
+// buildEndpointRequest builds the request body for the configured endpoint type.
+// Slack endpoints only carry a url and/or token and PagerDuty endpoints a routing
+// key, while http endpoints need the method and authentication settings.
+func (r *NotificationEndpointResource) buildEndpointRequest(ctx context.Context, data *NotificationEndpointResourceModel, orgID string) (NotificationEndpointRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
+	// PUT replaces the whole endpoint, so omitting the description clears it
 	endpointReq := NotificationEndpointRequest{
-		Name:       data.Name.ValueString(),
-		Type:       data.Type.ValueString(),
-		URL:        data.URL.ValueString(),
-		Status:     data.Status.ValueString(),
-		Method:     data.Method.ValueString(),
-		AuthMethod: data.AuthMethod.ValueString(),
-		OrgID:      *orgObj.Id,
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Type:        data.Type.ValueString(),
+		Status:      data.Status.ValueString(),
+		OrgID:       orgID,
+	}
+
+	// Required attributes per type are enforced by the config validators
+	switch endpointReq.Type {
+	case "slack":
+		endpointReq.URL = data.URL.ValueString()
+		endpointReq.Token = credentialValue(data.Token, data.TokenSecretKey)
+		return endpointReq, diags
+	case "pagerduty":
+		endpointReq.RoutingKey = data.RoutingKey.ValueString()
+		endpointReq.ClientURL = data.ClientURL.ValueString()
+		return endpointReq, diags
+	}
+
+	endpointReq.URL = data.URL.ValueString()
+	endpointReq.Method = data.Method.ValueString()
+	endpointReq.AuthMethod = data.AuthMethod.ValueString()
+
+	// Only send the credentials matching the authentication method
+	switch endpointReq.AuthMethod {
+	case "basic":
+		endpointReq.Username = data.Username.ValueString()
+		endpointReq.Password = credentialValue(data.Password, data.PasswordSecretKey)
+	case "bearer":
+		endpointReq.Token = credentialValue(data.Token, data.TokenSecretKey)
 	}
 
 	// Add headers if provided
 	if !data.Headers.IsNull() {
 		headers := make(map[string]string)
-		diags := data.Headers.ElementsAs(ctx, &headers, false)
+		diags.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
 		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
+			return endpointReq, diags
 		}
 		endpointReq.Headers = headers
 	}
@@ -226,38 +446,196 @@ func (r *NotificationEndpointResource) Create(ctx context.Context, req resource.
 		endpointReq.ContentTemplate = &template
 	}
 
-	// Make HTTP request
-	jsonData, err := json.Marshal(endpointReq)
+	return endpointReq, diags
+}
+
+// credentialValue returns the credential to send in the endpoint body, which is a
+// reference to the organization secret when a secret key is configured
+func credentialValue(value, secretKey types.String) string {
+	if !secretKey.IsNull() && secretKey.ValueString() != "" {
+		return "secret: " + secretKey.ValueString()
+	}
+	return value.ValueString()
+}
+
+// storeSecrets writes credentials with a configured secret key to the organization
+// secrets, so the endpoint body only has to reference them
+func (r *NotificationEndpointResource) storeSecrets(ctx context.Context, data *NotificationEndpointResourceModel, orgID string) error {
+	secrets := domain.Secrets{}
+	if !data.TokenSecretKey.IsNull() && !data.Token.IsNull() {
+		secrets.Set(data.TokenSecretKey.ValueString(), data.Token.ValueString())
+	}
+	if !data.PasswordSecretKey.IsNull() && !data.Password.IsNull() {
+		secrets.Set(data.PasswordSecretKey.ValueString(), data.Password.ValueString())
+	}
+	if len(secrets.AdditionalProperties) == 0 {
+		return nil
+	}
+
+	return r.client.APIClient().PatchOrgsIDSecrets(ctx, &domain.PatchOrgsIDSecretsAllParams{
+		OrgID: orgID,
+		Body:  domain.PatchOrgsIDSecretsJSONRequestBody(secrets),
+	})
+}
+
+// loadWriteOnlyCredentials copies the write-only credentials, which are only part
+// of the configuration, into the token and password of a request copy of the model
+func loadWriteOnlyCredentials(ctx context.Context, config tfsdk.Config, data *NotificationEndpointResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var token, password types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root("token_wo"), &token)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &password)...)
+	if !token.IsNull() {
+		data.Token = token
+	}
+	if !password.IsNull() {
+		data.Password = password
+	}
+
+	return diags
+}
+
+// refreshCredential reconciles a sensitive attribute with the value returned by the
+// API. Redacted values and secret references cannot be compared and keep the state
+// value, while a missing credential clears it so out of band removal shows as drift.
+func refreshCredential(state types.String, value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+	if strings.HasPrefix(*value, "secret:") || isRedacted(*value) {
+		return state
+	}
+	return types.StringValue(*value)
+}
+
+// refreshSecretKey returns the organization secret key a credential references.
+// Keys InfluxDB generates itself for plain credentials are not user managed and
+// map to null.
+func refreshSecretKey(value *string, generatedKey string) types.String {
+	if value == nil || !strings.HasPrefix(*value, "secret:") {
+		return types.StringNull()
+	}
+
+	key := strings.TrimSpace(strings.TrimPrefix(*value, "secret:"))
+	if key == "" || key == generatedKey {
+		return types.StringNull()
+	}
+	return types.StringValue(key)
+}
+
+// isRedacted reports whether a credential was masked by the API
+func isRedacted(value string) bool {
+	return strings.Trim(value, "*") == ""
+}
+
+// optionalString maps empty strings from the API to null, as the API omits
+// fields which do not apply to the endpoint type
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// syncLabels attaches and detaches labels so the endpoint carries exactly the configured labels
+func (r *NotificationEndpointResource) syncLabels(ctx context.Context, endpointID string, labels types.Set) error {
+	var desired []string
+	if !labels.IsNull() && !labels.IsUnknown() {
+		if diags := labels.ElementsAs(ctx, &desired, false); diags.HasError() {
+			return fmt.Errorf("unable to read labels from plan")
+		}
+	}
+
+	apiClient := r.client.APIClient()
+	current, err := apiClient.GetNotificationEndpointsIDLabels(ctx, &domain.GetNotificationEndpointsIDLabelsAllParams{
+		EndpointID: endpointID,
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] Serialization Error", fmt.Sprintf("Unable to serialize notification endpoint: %s", err))
+		return err
+	}
+
+	add, remove := diffLabels(labelIDs(current), desired)
+	for _, labelID := range add {
+		id := labelID
+		_, err := apiClient.PostNotificationEndpointIDLabels(ctx, &domain.PostNotificationEndpointIDLabelsAllParams{
+			EndpointID: endpointID,
+			Body:       domain.PostNotificationEndpointIDLabelsJSONRequestBody{LabelID: &id},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to attach label %s: %w", labelID, err)
+		}
+	}
+	for _, labelID := range remove {
+		err := apiClient.DeleteNotificationEndpointsIDLabelsID(ctx, &domain.DeleteNotificationEndpointsIDLabelsIDAllParams{
+			EndpointID: endpointID,
+			LabelID:    labelID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to detach label %s: %w", labelID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *NotificationEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NotificationEndpointResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpReq, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v2/notificationEndpoints", r.serverURL), bytes.NewBuffer(jsonData))
-	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+	createTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] HTTP Error", fmt.Sprintf("Unable to create notification endpoint: %s", err))
+	ctx, span := tracing.Start(ctx, "influxdb_notification_endpoint", "Create", "")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "create", "influxdb_notification_endpoint") {
 		return
 	}
-	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
 	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+		resp.Diagnostics.AddError("[CREATE STAGE] Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
+		return
+	}
+
+	// Write-only credentials only travel in this copy, so they never reach the state
+	requestData := data
+	resp.Diagnostics.Append(loadWriteOnlyCredentials(ctx, req.Config, &requestData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpointReq, diags := r.buildEndpointRequest(ctx, &requestData, orgID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.storeSecrets(ctx, &requestData, orgID); err != nil {
+		resp.Diagnostics.AddError("[CREATE STAGE] Secret Error", fmt.Sprintf("Unable to store notification endpoint credentials as secrets: %s", err))
 		return
 	}
 
-	if httpResp.StatusCode != http.StatusCreated {
-		resp.Diagnostics.AddError("[CREATE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	body, err := r.api.Do(ctx, http.MethodPost, "notificationEndpoints", endpointReq)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "[CREATE STAGE] API Error", fmt.Sprintf("Unable to create notification endpoint: %s", err), err, endpointErrorHints)
 		return
 	}
 
@@ -269,11 +647,35 @@ func (r *NotificationEndpointResource) Create(ctx context.Context, req resource.
 
 	// Update data with response
 	data.ID = types.StringValue(endpoint.ID)
-	data.Org = types.StringValue(org)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Report the provider default org, or the ID when the endpoint was created by org_id
+		if r.org != "" && data.OrgID.IsUnknown() {
+			data.Org = types.StringValue(r.org)
+		} else {
+			data.Org = types.StringValue(orgID)
+		}
+	}
+	data.OrgID = types.StringValue(orgID)
 	data.Status = types.StringValue(endpoint.Status)
-	data.Method = types.StringValue(endpoint.Method)
-	data.AuthMethod = types.StringValue(endpoint.AuthMethod)
+	data.CreatedAt = formatTimestamp(endpoint.CreatedAt)
+	data.UpdatedAt = formatTimestamp(endpoint.UpdatedAt)
+	data.Method = optionalString(endpoint.Method)
+	data.AuthMethod = optionalString(endpoint.AuthMethod)
 
+	// Labels can only be attached once the endpoint exists
+	if err := r.syncLabels(ctx, endpoint.ID, data.Labels); err != nil {
+		resp.Diagnostics.AddError("[CREATE STAGE] Label Error", fmt.Sprintf("Unable to attach labels to notification endpoint: %s", err))
+	}
+
+	// A failed verification keeps the endpoint in state as tainted, so it is recreated on the next apply
+	if data.VerifyOnCreate.ValueBool() && !resp.Diagnostics.HasError() {
+		requestData.ID = data.ID
+		if err := r.sendTestNotification(ctx, &requestData); err != nil {
+			resp.Diagnostics.AddError("[CREATE STAGE] Verification Error", fmt.Sprintf("Notification endpoint was created but the test notification failed: %s", err))
+		}
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -286,37 +688,30 @@ func (r *NotificationEndpointResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	// Make HTTP request to get notification endpoint
-	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v2/notificationEndpoints/%s", r.serverURL, data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("[READ STAGE] Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Accept", "application/json")
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("[READ STAGE] HTTP Error", fmt.Sprintf("Unable to read notification endpoint: %s", err))
-		return
-	}
-	defer httpResp.Body.Close()
+	ctx, span := tracing.Start(ctx, "influxdb_notification_endpoint", "Read", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
 
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddWarning("[READ STAGE] Resource Not Found", "Notification endpoint not found, removing from state")
-		resp.State.RemoveResource(ctx)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("[READ STAGE] Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+	// Refreshes are served from one listing of the org's endpoints
+	body, err := r.batch.Get(ctx, "notificationEndpoints", data.OrgID.ValueString(), data.ID.ValueString())
+	if removeIfNotFound(ctx, err, resp) {
 		return
 	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("[READ STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	if err != nil {
+		resp.Diagnostics.AddError("[READ STAGE] API Error", fmt.Sprintf("Unable to read notification endpoint: %s", err))
 		return
 	}
 
@@ -326,17 +721,55 @@ func (r *NotificationEndpointResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	// Endpoints of other types, e.g. created in the UI, cannot be represented by this resource
+	if _, ok := supportedEndpointTypes[endpoint.Type]; !ok {
+		resp.Diagnostics.AddError("[READ STAGE] Unsupported Endpoint Type", fmt.Sprintf("Notification endpoint %s has type %q, only http, slack and pagerduty endpoints are supported", endpoint.ID, endpoint.Type))
+		return
+	}
+
 	// Update data with response
 	data.Name = types.StringValue(endpoint.Name)
+	data.OrgID = types.StringValue(endpoint.OrgID)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Only unknown after import
+		data.Org = types.StringValue(orgNameOrID(ctx, r.orgs, endpoint.OrgID))
+	}
 	if endpoint.Description != nil {
-		data.Description = types.StringValue(*endpoint.Description)
+		data.Description = optionalString(*endpoint.Description)
+	} else {
+		data.Description = types.StringNull()
 	}
 	data.Status = types.StringValue(endpoint.Status)
 	data.Type = types.StringValue(endpoint.Type)
-	data.URL = types.StringValue(endpoint.URL)
-	data.Method = types.StringValue(endpoint.Method)
-	data.AuthMethod = types.StringValue(endpoint.AuthMethod)
-
+	data.CreatedAt = formatTimestamp(endpoint.CreatedAt)
+	data.UpdatedAt = formatTimestamp(endpoint.UpdatedAt)
+	if data.VerifyOnCreate.IsNull() {
+		// Only null after import, verification is irrelevant for existing endpoints
+		data.VerifyOnCreate = types.BoolValue(false)
+	}
+	data.URL = optionalString(endpoint.URL)
+	data.Method = optionalString(endpoint.Method)
+	data.AuthMethod = optionalString(endpoint.AuthMethod)
+	data.ClientURL = optionalString(endpoint.ClientURL)
+	if endpoint.Username != nil {
+		data.Username = optionalString(*endpoint.Username)
+	} else {
+		data.Username = types.StringNull()
+	}
+
+	// The API only returns secret references for credentials, so keep the configured values
+	// Credentials managed as write-only values are never stored in state
+	if data.TokenWOVersion.IsNull() {
+		data.Token = refreshCredential(data.Token, endpoint.Token)
+	}
+	if data.PasswordWOVersion.IsNull() {
+		data.Password = refreshCredential(data.Password, endpoint.Password)
+	}
+	data.RoutingKey = refreshCredential(data.RoutingKey, endpoint.RoutingKey)
+	data.TokenSecretKey = refreshSecretKey(endpoint.Token, endpoint.ID+"-token")
+	data.PasswordSecretKey = refreshSecretKey(endpoint.Password, endpoint.ID+"-password")
+
+	// Headers removed outside of Terraform are cleared so they show up as drift
 	if len(endpoint.Headers) > 0 {
 		headers, diags := types.MapValueFrom(ctx, types.StringType, endpoint.Headers)
 		resp.Diagnostics.Append(diags...)
@@ -344,12 +777,36 @@ func (r *NotificationEndpointResource) Read(ctx context.Context, req resource.Re
 			return
 		}
 		data.Headers = headers
+	} else {
+		data.Headers = types.MapNull(types.StringType)
 	}
 
 	if endpoint.ContentTemplate != nil {
-		data.ContentTemplate = types.StringValue(*endpoint.ContentTemplate)
+		data.ContentTemplate = optionalString(*endpoint.ContentTemplate)
+	} else {
+		data.ContentTemplate = types.StringNull()
 	}
 
+	labels, err := r.client.APIClient().GetNotificationEndpointsIDLabels(ctx, &domain.GetNotificationEndpointsIDLabelsAllParams{
+		EndpointID: data.ID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("[READ STAGE] Label Error", fmt.Sprintf("Unable to read notification endpoint labels: %s", err))
+		return
+	}
+	labelSet, diags := labelSetValue(ctx, labelIDs(labels))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Labels = labelSet
+
+	// skip_destroy only exists in Terraform, e.g. an import starts without it
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -358,83 +815,62 @@ func (r *NotificationEndpointResource) Update(ctx context.Context, req resource.
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	// Use the ID from state, the planned ID is only known when the plan kept it
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &data.ID)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	org := r.org
-	if !data.Org.IsNull() {
-		org = data.Org.ValueString()
-	}
-
-	// Get org ID
-	orgAPI := r.client.OrganizationsAPI()
-	orgObj, err := orgAPI.FindOrganizationByName(ctx, org)
-	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] Client Error", fmt.Sprintf("Unable to find organization %s, got error: %s", org, err))
+	updateTimeout, timeoutDiags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Prepare request with user-provided values
-	endpointReq := NotificationEndpointRequest{
-		Name:       data.Name.ValueString(),
-		Type:       data.Type.ValueString(),
-		URL:        data.URL.ValueString(),
-		Status:     data.Status.ValueString(),
-		Method:     data.Method.ValueString(),
-		AuthMethod: data.AuthMethod.ValueString(),
-		OrgID:      *orgObj.Id,
-	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	// Add headers if provided
-	if !data.Headers.IsNull() {
-		headers := make(map[string]string)
-		diags := data.Headers.ElementsAs(ctx, &headers, false)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-		endpointReq.Headers = headers
-	}
+	ctx, span := tracing.Start(ctx, "influxdb_notification_endpoint", "Update", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
 
-	// Add content template if provided
-	if !data.ContentTemplate.IsNull() {
-		template := data.ContentTemplate.ValueString()
-		endpointReq.ContentTemplate = &template
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Make HTTP request
-	jsonData, err := json.Marshal(endpointReq)
-	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] Serialization Error", fmt.Sprintf("Unable to serialize notification endpoint: %s", err))
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "update", "influxdb_notification_endpoint") {
 		return
 	}
 
-	httpReq, err := http.NewRequest("PUT", fmt.Sprintf("%s/api/v2/notificationEndpoints/%s", r.serverURL, data.ID.ValueString()), bytes.NewBuffer(jsonData))
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
 	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+		resp.Diagnostics.AddError("[UPDATE STAGE] Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
+	// PUT replaces the whole endpoint, so send the complete type-specific object
+	// including description, headers and credentials rather than only changed fields
+	requestData := data
+	resp.Diagnostics.Append(loadWriteOnlyCredentials(ctx, req.Config, &requestData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] HTTP Error", fmt.Sprintf("Unable to update notification endpoint: %s", err))
+	endpointReq, diags := r.buildEndpointRequest(ctx, &requestData, orgID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+	if err := r.storeSecrets(ctx, &requestData, orgID); err != nil {
+		resp.Diagnostics.AddError("[UPDATE STAGE] Secret Error", fmt.Sprintf("Unable to store notification endpoint credentials as secrets: %s", err))
 		return
 	}
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("[UPDATE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	body, err := r.api.Do(ctx, http.MethodPut, "notificationEndpoints/"+data.ID.ValueString(), endpointReq)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "[UPDATE STAGE] API Error", fmt.Sprintf("Unable to update notification endpoint: %s", err), err, endpointErrorHints)
 		return
 	}
 
@@ -445,10 +881,18 @@ func (r *NotificationEndpointResource) Update(ctx context.Context, req resource.
 	}
 
 	// Update data with response
+	data.OrgID = types.StringValue(endpoint.OrgID)
 	data.Status = types.StringValue(endpoint.Status)
-	data.Method = types.StringValue(endpoint.Method)
-	data.AuthMethod = types.StringValue(endpoint.AuthMethod)
+	data.UpdatedAt = formatTimestamp(endpoint.UpdatedAt)
+	data.Method = optionalString(endpoint.Method)
+	data.AuthMethod = optionalString(endpoint.AuthMethod)
 
+	if err := r.syncLabels(ctx, endpoint.ID, data.Labels); err != nil {
+		resp.Diagnostics.AddError("[UPDATE STAGE] Label Error", fmt.Sprintf("Unable to update notification endpoint labels: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -461,29 +905,66 @@ func (r *NotificationEndpointResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	// Make HTTP request to delete notification endpoint
-	httpReq, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v2/notificationEndpoints/%s", r.serverURL, data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("[DELETE STAGE] Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+	// The notification endpoint is kept in InfluxDB, Terraform drops it from the state
+	if data.SkipDestroy.ValueBool() {
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
+	deleteTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("[DELETE STAGE] HTTP Error", fmt.Sprintf("Unable to delete notification endpoint: %s", err))
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_notification_endpoint", "Delete", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(httpResp.Body)
-		resp.Diagnostics.AddError("[DELETE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "delete", "influxdb_notification_endpoint") {
+		return
+	}
+
+	// An endpoint which is already gone needs no deletion
+	_, err := r.api.Do(ctx, http.MethodDelete, "notificationEndpoints/"+data.ID.ValueString(), nil)
+	if err != nil && !errors.Is(err, apiclient.ErrNotFound) {
+		resp.Diagnostics.AddError("[DELETE STAGE] API Error", fmt.Sprintf("Unable to delete notification endpoint: %s", err))
 		return
 	}
 }
 
+// ImportState imports an endpoint by ID or by `org/name`. The following Read fills
+// in all attributes of the endpoint type, except credentials which the API never
+// returns in plain text and are taken from the configuration on the next apply.
 func (r *NotificationEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importByIDOrName(ctx, req, resp, r.orgs, r.org, r.url, "notification endpoint", func(ctx context.Context, orgID, name string) ([]string, error) {
+		return listIDsByName(ctx, r.api, "notificationEndpoints", orgID, name)
+	})
+}
+
+func (r *NotificationEndpointResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listConfigSchema()
+}
+
+func (r *NotificationEndpointResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.unconfigured.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(r.unconfigured)
+		return
+	}
+
+	listCollection(ctx, req, stream, r, r.api, r.orgs, r.org, r.url, "notificationEndpoints", func(member listMember) bool {
+		_, ok := supportedEndpointTypes[member.Type]
+		return ok
+	})
 }
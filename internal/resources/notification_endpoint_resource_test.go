@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// httpEndpointAttributes returns the attributes of a planned HTTP endpoint with
+// bearer authentication
+func httpEndpointAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "alerts",
+		"org_id":      testOrgID,
+		"status":      "active",
+		"type":        "http",
+		"url":         "https://alerts.example.com/hook",
+		"method":      "POST",
+		"auth_method": "bearer",
+		"token":       "hook-token",
+		"username":    "ignored",
+		"headers": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"X-Team": types.StringValue("ops"),
+		}),
+	}
+}
+
+func TestNotificationEndpointCreatePayload(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "notificationEndpoints", echoCreated("0000000000000002"))
+	api.respond(http.MethodGet, "notificationEndpoints/0000000000000002/labels", http.StatusOK, `{"labels":[]}`)
+
+	r := NewNotificationEndpointResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, httpEndpointAttributes())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+
+	request := api.lastRequest(http.MethodPost, "notificationEndpoints")
+	if got := request.Header.Get("Authorization"); got != "Token "+testToken {
+		t.Errorf("got Authorization %q", got)
+	}
+
+	body := request.JSON(t)
+	want := map[string]interface{}{
+		"name":       "alerts",
+		"orgID":      testOrgID,
+		"status":     "active",
+		"type":       "http",
+		"url":        "https://alerts.example.com/hook",
+		"method":     "POST",
+		"authMethod": "bearer",
+		"token":      "hook-token",
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("got %s %v, want %v", key, body[key], value)
+		}
+	}
+	if headers, _ := body["headers"].(map[string]interface{}); headers["X-Team"] != "ops" {
+		t.Errorf("got headers %v", body["headers"])
+	}
+	// Only the credentials of the authentication method are sent
+	if _, ok := body["username"]; ok {
+		t.Errorf("bearer endpoint sent a username: %s", request.Body)
+	}
+
+	if id := stateString(t, resp.State, "id"); id.ValueString() != "0000000000000002" {
+		t.Errorf("got id %s", id)
+	}
+}
+
+func TestNotificationEndpointCreatePagerDuty(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPost, "notificationEndpoints", echoCreated("0000000000000002"))
+	api.respond(http.MethodGet, "notificationEndpoints/0000000000000002/labels", http.StatusOK, `{"labels":[]}`)
+
+	r := NewNotificationEndpointResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, map[string]interface{}{
+		"name":        "pager",
+		"org_id":      testOrgID,
+		"status":      "active",
+		"type":        "pagerduty",
+		"routing_key": "routing",
+		"client_url":  "https://example.com/incidents",
+		"url":         "https://ignored.example.com",
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+
+	body := api.lastRequest(http.MethodPost, "notificationEndpoints").JSON(t)
+	if body["routingKey"] != "routing" || body["clientURL"] != "https://example.com/incidents" {
+		t.Errorf("got body %v", body)
+	}
+	if _, ok := body["url"]; ok {
+		t.Errorf("PagerDuty endpoint sent a URL: %v", body)
+	}
+}
+
+func TestNotificationEndpointUpdateUsesStateID(t *testing.T) {
+	api := newMockAPI(t)
+	api.handle(http.MethodPut, "notificationEndpoints/0000000000000002", echo(http.StatusOK, "0000000000000002"))
+	api.respond(http.MethodGet, "notificationEndpoints/0000000000000002/labels", http.StatusOK, `{"labels":[]}`)
+
+	prior := httpEndpointAttributes()
+	prior["id"] = "0000000000000002"
+	planned := httpEndpointAttributes()
+	planned["id"] = types.StringUnknown()
+	planned["url"] = "https://alerts.example.com/other"
+
+	r := NewNotificationEndpointResource()
+	configure(t, r, api.providerData(0))
+	resp := update(t, r, prior, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %v", resp.Diagnostics)
+	}
+
+	// PUT replaces the whole endpoint
+	body := api.lastRequest(http.MethodPut, "notificationEndpoints/0000000000000002").JSON(t)
+	if body["url"] != "https://alerts.example.com/other" || body["token"] != "hook-token" || body["name"] != "alerts" {
+		t.Errorf("got body %v", body)
+	}
+	if id := stateString(t, resp.State, "id"); id.ValueString() != "0000000000000002" {
+		t.Errorf("got id %s", id)
+	}
+}
@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.ActionWithConfigure = &NotificationEndpointTestAction{}
+
+func NewNotificationEndpointTestAction() action.Action {
+	return &NotificationEndpointTestAction{}
+}
+
+// NotificationEndpointTestAction sends a test notification through an existing
+// notification endpoint
+type NotificationEndpointTestAction struct {
+	api          *apiclient.Client
+	httpClient   *http.Client
+	unconfigured diag.Diagnostics
+}
+
+// NotificationEndpointTestActionModel describes the action data model.
+type NotificationEndpointTestActionModel struct {
+	EndpointID types.String `tfsdk:"endpoint_id"`
+	Token      types.String `tfsdk:"token"`
+	Password   types.String `tfsdk:"password"`
+	RoutingKey types.String `tfsdk:"routing_key"`
+}
+
+func (a *NotificationEndpointTestAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_endpoint_test"
+}
+
+func (a *NotificationEndpointTestAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a test notification through a notification endpoint, the same way `verify_on_create` does. InfluxDB does not return the credentials of endpoints, so the credentials the destination requires have to be passed to the action. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"endpoint_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the notification endpoint to test",
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Token of http endpoints using bearer authentication",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Password of http endpoints using basic authentication",
+			},
+			"routing_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Routing key of pagerduty endpoints",
+			},
+		},
+	}
+}
+
+func (a *NotificationEndpointTestAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.api = providerData.API
+	a.httpClient = providerData.HTTPClient
+	a.unconfigured = providerData.Unconfigured
+}
+
+func (a *NotificationEndpointTestAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	resp.Diagnostics.Append(a.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config NotificationEndpointTestActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := a.api.Do(ctx, http.MethodGet, "notificationEndpoints/"+config.EndpointID.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Invoke - API Error", fmt.Sprintf("Unable to read notification endpoint: %s", err))
+		return
+	}
+
+	var endpoint NotificationEndpointResponse
+	if err := json.Unmarshal(body, &endpoint); err != nil {
+		resp.Diagnostics.AddError("Invoke - Deserialization Error", fmt.Sprintf("Unable to parse notification endpoint response: %s", err))
+		return
+	}
+
+	if _, ok := supportedEndpointTypes[endpoint.Type]; !ok {
+		resp.Diagnostics.AddError("Invoke - Unsupported Endpoint Type", fmt.Sprintf("Notification endpoint %s has type %q, only http, slack and pagerduty endpoints can be tested", endpoint.ID, endpoint.Type))
+		return
+	}
+
+	headers := types.MapNull(types.StringType)
+	if len(endpoint.Headers) > 0 {
+		var diags diag.Diagnostics
+		headers, diags = types.MapValueFrom(ctx, types.StringType, endpoint.Headers)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data := NotificationEndpointResourceModel{
+		ID:         types.StringValue(endpoint.ID),
+		Type:       types.StringValue(endpoint.Type),
+		URL:        optionalString(endpoint.URL),
+		Method:     optionalString(endpoint.Method),
+		AuthMethod: optionalString(endpoint.AuthMethod),
+		ClientURL:  optionalString(endpoint.ClientURL),
+		Username:   types.StringPointerValue(endpoint.Username),
+		Headers:    headers,
+		Token:      config.Token,
+		Password:   config.Password,
+		RoutingKey: config.RoutingKey,
+	}
+
+	switch {
+	case endpoint.Type == "pagerduty" && data.RoutingKey.IsNull():
+		resp.Diagnostics.AddError("Invoke - Missing Credential", "Testing a pagerduty endpoint requires its routing_key")
+		return
+	case endpoint.AuthMethod == "basic" && data.Password.IsNull():
+		resp.Diagnostics.AddError("Invoke - Missing Credential", "Testing an http endpoint with basic authentication requires its password")
+		return
+	case endpoint.AuthMethod == "bearer" && data.Token.IsNull():
+		resp.Diagnostics.AddError("Invoke - Missing Credential", "Testing an http endpoint with bearer authentication requires its token")
+		return
+	}
+
+	sender := &NotificationEndpointResource{httpClient: a.httpClient}
+	if err := sender.sendTestNotification(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Invoke - Verification Failed", fmt.Sprintf("Notification endpoint %s did not accept the test notification: %s", endpoint.ID, err))
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Delivered a test notification through notification endpoint %s", endpoint.ID)})
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint InfluxDB sends alerts to
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// verificationMessage is the text of the test notification sent by verify_on_create
+const verificationMessage = "Test notification sent by Terraform to verify this InfluxDB notification endpoint"
+
+// verificationTimeout bounds the delivery of a test notification
+const verificationTimeout = 30 * time.Second
+
+// sendTestNotification delivers a test notification through the destination of
+// the endpoint, mirroring the request InfluxDB would send for an alert
+func (r *NotificationEndpointResource) sendTestNotification(ctx context.Context, data *NotificationEndpointResourceModel) error {
+	ctx, cancel := context.WithTimeout(ctx, verificationTimeout)
+	defer cancel()
+
+	switch data.Type.ValueString() {
+	case "slack":
+		if data.URL.IsNull() {
+			return fmt.Errorf("slack endpoints can only be verified when a webhook url is configured")
+		}
+		return r.postTestNotification(ctx, http.MethodPost, data.URL.ValueString(), map[string]string{
+			"text": verificationMessage,
+		}, nil)
+	case "pagerduty":
+		return r.sendPagerDutyTestNotification(ctx, data)
+	default:
+		body := map[string]string{
+			"_check_name": "terraform-verification",
+			"_level":      "ok",
+			"_message":    verificationMessage,
+		}
+
+		headers := map[string]string{}
+		if !data.Headers.IsNull() {
+			if diags := data.Headers.ElementsAs(ctx, &headers, false); diags.HasError() {
+				return fmt.Errorf("unable to read headers")
+			}
+		}
+
+		return r.postTestNotification(ctx, data.Method.ValueString(), data.URL.ValueString(), body, func(req *http.Request) {
+			for name, value := range headers {
+				req.Header.Set(name, value)
+			}
+			switch data.AuthMethod.ValueString() {
+			case "basic":
+				req.SetBasicAuth(data.Username.ValueString(), data.Password.ValueString())
+			case "bearer":
+				req.Header.Set("Authorization", "Bearer "+data.Token.ValueString())
+			}
+		})
+	}
+}
+
+// sendPagerDutyTestNotification triggers a test incident and resolves it right away
+func (r *NotificationEndpointResource) sendPagerDutyTestNotification(ctx context.Context, data *NotificationEndpointResourceModel) error {
+	dedupKey := fmt.Sprintf("terraform-verification-%s", data.ID.ValueString())
+
+	for _, action := range []string{"trigger", "resolve"} {
+		event := map[string]interface{}{
+			"routing_key":  data.RoutingKey.ValueString(),
+			"event_action": action,
+			"dedup_key":    dedupKey,
+			"payload": map[string]string{
+				"summary":  verificationMessage,
+				"source":   "terraform",
+				"severity": "info",
+			},
+		}
+		if !data.ClientURL.IsNull() {
+			event["client"] = "InfluxDB"
+			event["client_url"] = data.ClientURL.ValueString()
+		}
+
+		if err := r.postTestNotification(ctx, http.MethodPost, pagerDutyEventsURL, event, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postTestNotification sends a JSON test notification and fails on non-2xx responses
+func (r *NotificationEndpointResource) postTestNotification(ctx context.Context, method, url string, body interface{}, prepare func(*http.Request)) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create test notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if prepare != nil {
+		prepare(req)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver test notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("test notification was rejected with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
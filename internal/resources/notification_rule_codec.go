@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// decodeNotificationRule decodes a rule body into the generated domain type matching
+// its type. The generated domain.NotificationRule wrapper embeds an interface and
+// therefore cannot decode polymorphic rule bodies on its own.
+func decodeNotificationRule(body []byte) (domain.NotificationRuleDiscriminator, error) {
+	var discriminator typeDiscriminator
+	if err := json.Unmarshal(body, &discriminator); err != nil {
+		return nil, err
+	}
+
+	var rule domain.NotificationRuleDiscriminator
+	switch discriminator.Type {
+	case string(domain.SlackNotificationRuleBaseTypeSlack):
+		rule = &domain.SlackNotificationRule{}
+	case string(domain.PagerDutyNotificationRuleBaseTypePagerduty):
+		rule = &domain.PagerDutyNotificationRule{}
+	case string(domain.HTTPNotificationRuleBaseTypeHttp):
+		rule = &domain.HTTPNotificationRule{}
+	default:
+		return nil, fmt.Errorf("unsupported notification rule type %q", discriminator.Type)
+	}
+
+	if err := json.Unmarshal(body, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// notificationRuleFields returns the common fields of a decoded rule together with
+// its type and the type specific message template and channel
+func notificationRuleFields(rule domain.NotificationRuleDiscriminator) (base domain.NotificationRuleBase, ruleType string, messageTemplate, channel *string) {
+	switch r := rule.(type) {
+	case *domain.SlackNotificationRule:
+		return r.NotificationRuleBase, string(r.Type), &r.MessageTemplate, r.Channel
+	case *domain.PagerDutyNotificationRule:
+		return r.NotificationRuleBase, string(r.Type), &r.MessageTemplate, nil
+	case *domain.HTTPNotificationRule:
+		return r.NotificationRuleBase, string(r.Type), nil, nil
+	}
+	return domain.NotificationRuleBase{}, "", nil, nil
+}
@@ -1,52 +1,85 @@
 package resources
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NotificationRuleResource{}
 var _ resource.ResourceWithImportState = &NotificationRuleResource{}
+var _ resource.ResourceWithIdentity = &NotificationRuleResource{}
+var _ list.ListResourceWithConfigure = &NotificationRuleResource{}
+var _ resource.ResourceWithConfigValidators = &NotificationRuleResource{}
+var _ resource.ResourceWithModifyPlan = &NotificationRuleResource{}
 
 func NewNotificationRuleResource() resource.Resource {
 	return &NotificationRuleResource{}
 }
 
+func NewNotificationRuleListResource() list.ListResource {
+	return &NotificationRuleResource{}
+}
+
 // NotificationRuleResource defines the resource implementation.
 type NotificationRuleResource struct {
-	client     influxdb2.Client
-	org        string
-	serverURL  string
-	authToken  string
-	httpClient *http.Client
+	client              influxdb2.Client
+	org                 string
+	url                 string
+	orgs                *common.OrgCache
+	api                 *apiclient.Client
+	batch               *apiclient.Batch
+	readOnly            bool
+	ignoreNormalization bool
+	unconfigured        diag.Diagnostics
 }
 
 // NotificationRuleResourceModel describes the resource data model.
 type NotificationRuleResourceModel struct {
-	ID          types.String      `tfsdk:"id"`
-	Name        types.String      `tfsdk:"name"`
-	Org         types.String      `tfsdk:"org"`
-	Description types.String      `tfsdk:"description"`
-	Status      types.String      `tfsdk:"status"`
-	Type        types.String      `tfsdk:"type"`
-	EndpointID  types.String      `tfsdk:"endpoint_id"`
-	Every       types.String      `tfsdk:"every"`
-	Offset      types.String      `tfsdk:"offset"`
-	StatusRules []StatusRuleModel `tfsdk:"status_rules"`
-	TagRules    []TagRuleModel    `tfsdk:"tag_rules"`
+	ID                  types.String      `tfsdk:"id"`
+	Name                types.String      `tfsdk:"name"`
+	Org                 types.String      `tfsdk:"org"`
+	OrgID               types.String      `tfsdk:"org_id"`
+	Description         types.String      `tfsdk:"description"`
+	Status              types.String      `tfsdk:"status"`
+	Type                types.String      `tfsdk:"type"`
+	EndpointID          types.String      `tfsdk:"endpoint_id"`
+	Every               types.String      `tfsdk:"every"`
+	Offset              types.String      `tfsdk:"offset"`
+	MessageTemplate     types.String      `tfsdk:"message_template"`
+	Channel             types.String      `tfsdk:"channel"`
+	StatusRules         []StatusRuleModel `tfsdk:"status_rules"`
+	TagRules            []TagRuleModel    `tfsdk:"tag_rules"`
+	CreatedAt           types.String      `tfsdk:"created_at"`
+	UpdatedAt           types.String      `tfsdk:"updated_at"`
+	LatestCompleted     types.String      `tfsdk:"latest_completed"`
+	SkipDestroy         types.Bool        `tfsdk:"skip_destroy"`
+	IgnoreNormalization types.Bool        `tfsdk:"ignore_normalization"`
+	Timeouts            timeouts.Value    `tfsdk:"timeouts"`
 }
 
 type StatusRuleModel struct {
@@ -80,19 +113,38 @@ func (r *NotificationRuleResource) Schema(ctx context.Context, req resource.Sche
 			"org": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default. Moving the rule to another organization forces a new rule to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Organization ID. Can be used instead of `org` to skip the organization name lookup, e.g. with `org_id = influxdb_notification_endpoint.example.org_id`. Takes precedence over `org` when both are set, e.g. in configuration generated on import.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Notification rule description",
 			},
 			"status": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Status of the notification rule (active, inactive)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(domain.TaskStatusTypeActive)),
+				MarkdownDescription: "Status of the notification rule (active, inactive). Defaults to `active`.",
+				Validators: []validator.String{
+					validators.Status(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Type of the notification rule (http, slack, pagerduty)",
+				Validators: []validator.String{
+					stringvalidator.OneOf("http", "slack", "pagerduty"),
+				},
 			},
 			"endpoint_id": schema.StringAttribute{
 				Required:            true,
@@ -101,24 +153,72 @@ func (r *NotificationRuleResource) Schema(ctx context.Context, req resource.Sche
 			"every": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Check frequency (e.g., '1m', '5m')",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
 			},
 			"offset": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Offset duration before checking",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0s"),
+				MarkdownDescription: "Offset duration before checking. Defaults to `0s`.",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"message_template": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Template for the notification message, e.g. `Check ${ r._check_name } is ${ r._level }`. Required for slack and pagerduty rules, not supported by http rules. Whitespace-only changes are ignored unless `ignore_normalization` is disabled.",
+			},
+			"channel": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Slack channel to post to, overriding the channel of the Slack app or webhook (slack rules only)",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Notification rule creation timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Notification rule last update timestamp",
+			},
+			"latest_completed": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the latest completed notification rule run",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ignore_normalization": ignoreNormalizationAttribute(),
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only remove the notification rule from the Terraform state on destroy and keep it in InfluxDB. Only takes effect once applied, before the destroy. Defaults to `false`.",
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 			"status_rules": schema.ListNestedBlock{
 				MarkdownDescription: "Rules based on check status levels",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"current_level": schema.StringAttribute{
 							Required:            true,
-							MarkdownDescription: "Current status level (OK, INFO, WARN, CRIT)",
+							MarkdownDescription: "Current status level (OK, INFO, WARN, CRIT, UNKNOWN, ANY)",
+							Validators: []validator.String{
+								validators.RuleLevel(),
+							},
 						},
 						"previous_level": schema.StringAttribute{
 							Optional:            true,
-							MarkdownDescription: "Previous status level (OK, INFO, WARN, CRIT)",
+							MarkdownDescription: "Previous status level (OK, INFO, WARN, CRIT, UNKNOWN, ANY)",
+							Validators: []validator.String{
+								validators.RuleLevel(),
+							},
 						},
 					},
 				},
@@ -137,7 +237,15 @@ func (r *NotificationRuleResource) Schema(ctx context.Context, req resource.Sche
 						},
 						"operator": schema.StringAttribute{
 							Required:            true,
-							MarkdownDescription: "Operator for comparison (equal, notEqual, equalRegex, notEqualRegex)",
+							MarkdownDescription: "Operator for comparison (equal, notequal, equalregex, notequalregex)",
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(domain.TagRuleOperatorEqual),
+									string(domain.TagRuleOperatorNotequal),
+									string(domain.TagRuleOperatorEqualregex),
+									string(domain.TagRuleOperatorNotequalregex),
+								),
+							},
 						},
 					},
 				},
@@ -146,6 +254,100 @@ func (r *NotificationRuleResource) Schema(ctx context.Context, req resource.Sche
 	}
 }
 
+func (r *NotificationRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema()
+}
+
+func (r *NotificationRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validators.OffsetBeforeEvery(path.Root("every"), path.Root("offset")),
+		validators.RequiredWhen(path.Root("type"), "slack", path.Root("message_template")),
+		validators.RequiredWhen(path.Root("type"), "pagerduty", path.Root("message_template")),
+	}
+}
+
+// ModifyPlan rejects new rules on servers without notifications, replaces rules
+// moved to another organization and validates that the rule type matches the
+// type of its notification endpoint. Terraform does not expose other resources
+// to a plan, so this is only possible once the endpoint exists and its ID is
+// known.
+func (r *NotificationRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireManagementAPI(ctx, r.api, r.unconfigured, req, resp, "influxdb_notification_rule")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignoreNormalizedChanges(ctx, req, resp, r.ignoreNormalization, map[string]normalizer{
+		"message_template": common.NormalizeFlux,
+		"every":            normalizeDuration,
+		"offset":           normalizeDuration,
+	})
+
+	requireReplaceOnOrgChange(ctx, req, resp, r.orgs, r.org, r.unconfigured)
+
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var ruleType, endpointID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &ruleType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("endpoint_id"), &endpointID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ruleType.IsUnknown() || ruleType.IsNull() || endpointID.IsUnknown() || endpointID.IsNull() {
+		return
+	}
+
+	// The endpoint was already checked when the rule was created or last changed
+	if !req.State.Raw.IsNull() {
+		var stateType, stateEndpointID types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &stateType)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("endpoint_id"), &stateEndpointID)...)
+		if resp.Diagnostics.HasError() || (ruleType.Equal(stateType) && endpointID.Equal(stateEndpointID)) {
+			return
+		}
+	}
+
+	r.checkEndpointType(ctx, &resp.Diagnostics, ruleType.ValueString(), endpointID.ValueString())
+}
+
+// checkEndpointType reports a rule type that does not match the type of the
+// notification endpoint. Lookup failures are left to the API request of the
+// rule, so an unreachable server does not block planning.
+func (r *NotificationRuleResource) checkEndpointType(ctx context.Context, diags *diag.Diagnostics, ruleType, endpointID string) {
+	endpointType, err := r.fetchEndpointType(ctx, endpointID)
+	if err != nil || endpointType == "" {
+		return
+	}
+
+	if endpointType != ruleType {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Notification Rule Type Mismatch",
+			fmt.Sprintf("The rule type %q does not match the type %q of notification endpoint %s. Rules can only send to endpoints of the same type.", ruleType, endpointType, endpointID),
+		)
+	}
+}
+
+// fetchEndpointType returns the type of the notification endpoint with the given ID
+func (r *NotificationRuleResource) fetchEndpointType(ctx context.Context, endpointID string) (string, error) {
+	body, err := r.api.Do(ctx, http.MethodGet, "notificationEndpoints/"+endpointID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var endpoint struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &endpoint); err != nil {
+		return "", err
+	}
+
+	return endpoint.Type, nil
+}
+
 func (r *NotificationRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -164,64 +366,143 @@ func (r *NotificationRuleResource) Configure(ctx context.Context, req resource.C
 
 	r.client = providerData.Client
 	r.org = providerData.Org
-	r.serverURL = providerData.URL
-	r.authToken = providerData.Token
-	r.httpClient = &http.Client{}
+	r.url = providerData.URL
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+	r.ignoreNormalization = providerData.IgnoreNormalization
+	r.api = providerData.API
+	r.batch = providerData.Batch
 }
 
-type StatusRule struct {
-	CurrentLevel  string `json:"currentLevel"`
-	PreviousLevel string `json:"previousLevel,omitempty"`
+// buildNotificationRule builds the generated rule type matching the configured rule
+// type. Slack rules carry a channel and message template and PagerDuty rules a
+// message template in addition to the common fields, while http rules have neither.
+func buildNotificationRule(data *NotificationRuleResourceModel, ownerID, orgID string) (domain.NotificationRuleDiscriminator, error) {
+	every := data.Every.ValueString()
+	offset := data.Offset.ValueString()
+
+	base := domain.NotificationRuleBase{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueStringPointer(),
+		Status:      domain.TaskStatusType(data.Status.ValueString()),
+		EndpointID:  data.EndpointID.ValueString(),
+		OwnerID:     &ownerID,
+		Every:       &every,
+		Offset:      &offset,
+		OrgID:       orgID,
+		StatusRules: buildStatusRules(data.StatusRules),
+		TagRules:    buildTagRules(data.TagRules),
+	}
+	if !data.ID.IsNull() && !data.ID.IsUnknown() {
+		base.Id = data.ID.ValueStringPointer()
+	}
+
+	switch data.Type.ValueString() {
+	case string(domain.SlackNotificationRuleBaseTypeSlack):
+		return &domain.SlackNotificationRule{
+			NotificationRuleBase: base,
+			SlackNotificationRuleBase: domain.SlackNotificationRuleBase{
+				Channel:         data.Channel.ValueStringPointer(),
+				MessageTemplate: data.MessageTemplate.ValueString(),
+				Type:            domain.SlackNotificationRuleBaseTypeSlack,
+			},
+		}, nil
+	case string(domain.PagerDutyNotificationRuleBaseTypePagerduty):
+		return &domain.PagerDutyNotificationRule{
+			NotificationRuleBase: base,
+			PagerDutyNotificationRuleBase: domain.PagerDutyNotificationRuleBase{
+				MessageTemplate: data.MessageTemplate.ValueString(),
+				Type:            domain.PagerDutyNotificationRuleBaseTypePagerduty,
+			},
+		}, nil
+	case string(domain.HTTPNotificationRuleBaseTypeHttp):
+		return &domain.HTTPNotificationRule{
+			NotificationRuleBase: base,
+			HTTPNotificationRuleBase: domain.HTTPNotificationRuleBase{
+				Type: domain.HTTPNotificationRuleBaseTypeHttp,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notification rule type %q, expected http, slack or pagerduty", data.Type.ValueString())
+	}
 }
 
-type TagRule struct {
-	Key      string `json:"key"`
-	Value    string `json:"value"`
-	Operator string `json:"operator"`
+// buildStatusRules converts the status rule models into their API representation.
+// The API expects an empty list rather than null when no status rules are set.
+func buildStatusRules(rules []StatusRuleModel) []domain.StatusRule {
+	statusRules := make([]domain.StatusRule, len(rules))
+	for i, rule := range rules {
+		currentLevel := domain.RuleStatusLevel(rule.CurrentLevel.ValueString())
+		statusRules[i] = domain.StatusRule{
+			CurrentLevel: &currentLevel,
+		}
+		if !rule.PreviousLevel.IsNull() {
+			previousLevel := domain.RuleStatusLevel(rule.PreviousLevel.ValueString())
+			statusRules[i].PreviousLevel = &previousLevel
+		}
+	}
+	return statusRules
 }
 
-type NotificationRuleRequest struct {
-	Name            string       `json:"name"`
-	Description     *string      `json:"description,omitempty"`
-	Status          string       `json:"status"`
-	Type            string       `json:"type"`
-	EndpointID      string       `json:"endpointID"`
-	OwnerID         string       `json:"ownerID"`
-	Every           string       `json:"every"`
-	Offset          *string      `json:"offset,omitempty"`
-	MessageTemplate *string      `json:"messageTemplate,omitempty"`
-	StatusRules     []StatusRule `json:"statusRules"`
-	TagRules        []TagRule    `json:"tagRules,omitempty"`
-	OrgID           string       `json:"orgID"`
+// buildTagRules converts the tag rule models into their API representation
+func buildTagRules(rules []TagRuleModel) *[]domain.TagRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	tagRules := make([]domain.TagRule, len(rules))
+	for i, rule := range rules {
+		operator := domain.TagRuleOperator(rule.Operator.ValueString())
+		tagRules[i] = domain.TagRule{
+			Key:      rule.Key.ValueStringPointer(),
+			Value:    rule.Value.ValueStringPointer(),
+			Operator: &operator,
+		}
+	}
+	return &tagRules
 }
 
-type NotificationRuleUpdateRequest struct {
-	ID          string       `json:"id"`
-	Name        string       `json:"name"`
-	Description *string      `json:"description,omitempty"`
-	Status      string       `json:"status"`
-	Type        string       `json:"type"`
-	EndpointID  string       `json:"endpointID"`
-	OwnerID     string       `json:"ownerID"`
-	Every       string       `json:"every"`
-	Offset      *string      `json:"offset,omitempty"`
-	StatusRules []StatusRule `json:"statusRules"`
-	TagRules    []TagRule    `json:"tagRules,omitempty"`
-	OrgID       string       `json:"orgID"`
+// statusRuleModels converts API status rules into their models
+func statusRuleModels(rules []domain.StatusRule) []StatusRuleModel {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	statusRules := make([]StatusRuleModel, len(rules))
+	for i, rule := range rules {
+		statusRules[i] = StatusRuleModel{
+			CurrentLevel:  types.StringNull(),
+			PreviousLevel: types.StringNull(),
+		}
+		if rule.CurrentLevel != nil {
+			statusRules[i].CurrentLevel = types.StringValue(string(*rule.CurrentLevel))
+		}
+		if rule.PreviousLevel != nil {
+			statusRules[i].PreviousLevel = optionalString(string(*rule.PreviousLevel))
+		}
+	}
+	return statusRules
 }
 
-type NotificationRuleResponse struct {
-	ID          string       `json:"id"`
-	Name        string       `json:"name"`
-	Description *string      `json:"description"`
-	Status      string       `json:"status"`
-	Type        string       `json:"type"`
-	EndpointID  string       `json:"endpointID"`
-	Every       *string      `json:"every"`
-	Offset      *string      `json:"offset"`
-	StatusRules []StatusRule `json:"statusRules"`
-	TagRules    []TagRule    `json:"tagRules"`
-	OrgID       string       `json:"orgID"`
+// tagRuleModels converts API tag rules into their models
+func tagRuleModels(rules *[]domain.TagRule) []TagRuleModel {
+	if rules == nil || len(*rules) == 0 {
+		return nil
+	}
+
+	tagRules := make([]TagRuleModel, len(*rules))
+	for i, rule := range *rules {
+		tagRules[i] = TagRuleModel{
+			Key:      types.StringPointerValue(rule.Key),
+			Value:    types.StringPointerValue(rule.Value),
+			Operator: types.StringNull(),
+		}
+		if rule.Operator != nil {
+			tagRules[i].Operator = types.StringValue(string(*rule.Operator))
+		}
+	}
+	return tagRules
 }
 
 func (r *NotificationRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -233,104 +514,85 @@ func (r *NotificationRuleResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	org := r.org
-	if !data.Org.IsNull() {
-		org = data.Org.ValueString()
-	}
-
-	// Get org ID
-	orgAPI := r.client.OrganizationsAPI()
-	orgObj, err := orgAPI.FindOrganizationByName(ctx, org)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find organization %s, got error: %s", org, err))
+	createTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get the current user ID as the owner
-	userAPI := r.client.UsersAPI()
-	currentUser, err := userAPI.Me(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("[CREATE STAGE] User Error", fmt.Sprintf("Unable to get current user: %s", err))
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	// Prepare request with values from model
-	ruleReq := NotificationRuleRequest{
-		Name:        data.Name.ValueString(),
-		Status:      data.Status.ValueString(),
-		Type:        data.Type.ValueString(),
-		EndpointID:  data.EndpointID.ValueString(),
-		OwnerID:     *currentUser.Id,
-		Every:       data.Every.ValueString(),
-		OrgID:       *orgObj.Id,
-		StatusRules: []StatusRule{},
+	ctx, span := tracing.Start(ctx, "influxdb_notification_rule", "Create", "")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Set offset from model
-	offset := data.Offset.ValueString()
-	ruleReq.Offset = &offset
-
-	// Convert status rules
-	if len(data.StatusRules) > 0 {
-		statusRules := make([]StatusRule, len(data.StatusRules))
-		for i, rule := range data.StatusRules {
-			statusRules[i] = StatusRule{
-				CurrentLevel: rule.CurrentLevel.ValueString(),
-			}
-			if !rule.PreviousLevel.IsNull() {
-				statusRules[i].PreviousLevel = rule.PreviousLevel.ValueString()
-			}
-		}
-		ruleReq.StatusRules = statusRules
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "create", "influxdb_notification_rule") {
+		return
 	}
 
-	// Make HTTP request
-	jsonData, err := json.Marshal(ruleReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Serialization Error", fmt.Sprintf("Unable to serialize notification rule: %s", err))
+	// Endpoints created in the same apply were unknown during the plan
+	r.checkEndpointType(ctx, &resp.Diagnostics, data.Type.ValueString(), data.EndpointID.ValueString())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpReq, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v2/notificationRules", r.serverURL), bytes.NewBuffer(jsonData))
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
 	if err != nil {
-		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-
-	httpResp, err := r.httpClient.Do(httpReq)
+	// Get the current user ID as the owner
+	userAPI := r.client.UsersAPI()
+	currentUser, err := userAPI.Me(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Unable to create notification rule: %s", err))
+		resp.Diagnostics.AddError("[CREATE STAGE] User Error", fmt.Sprintf("Unable to get current user: %s", err))
 		return
 	}
-	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
+	// Prepare request with the full planned model
+	ruleReq, err := buildNotificationRule(&data, *currentUser.Id, orgID)
 	if err != nil {
-		resp.Diagnostics.AddError("Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+		resp.Diagnostics.AddError("[CREATE STAGE] Invalid Configuration", err.Error())
 		return
 	}
 
-	if httpResp.StatusCode != http.StatusCreated {
-		resp.Diagnostics.AddError("[CREATE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	body, err := r.api.Do(ctx, http.MethodPost, "notificationRules", ruleReq)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "[CREATE STAGE] API Error", fmt.Sprintf("Unable to create notification rule: %s", err), err, ruleErrorHints)
 		return
 	}
 
-	var rule NotificationRuleResponse
-	if err := json.Unmarshal(body, &rule); err != nil {
+	decodedRule, err := decodeNotificationRule(body)
+	if err != nil {
 		resp.Diagnostics.AddError("Deserialization Error", fmt.Sprintf("Unable to parse notification rule response: %s", err))
 		return
 	}
+	rule, ruleType, _, _ := notificationRuleFields(decodedRule)
 
 	// Update data with response
-	data.ID = types.StringValue(rule.ID)
-	data.Org = types.StringValue(org)
-	data.Status = types.StringValue(rule.Status)
-	data.Type = types.StringValue(rule.Type)
+	data.ID = types.StringPointerValue(rule.Id)
+	data.CreatedAt = formatTimestamp(rule.CreatedAt)
+	data.UpdatedAt = formatTimestamp(rule.UpdatedAt)
+	data.LatestCompleted = formatTimestamp(rule.LatestCompleted)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Report the provider default org, or the ID when the rule was created by org_id
+		if r.org != "" && data.OrgID.IsUnknown() {
+			data.Org = types.StringValue(r.org)
+		} else {
+			data.Org = types.StringValue(orgID)
+		}
+	}
+	data.OrgID = types.StringValue(orgID)
+	data.Status = types.StringValue(string(rule.Status))
+	data.Type = types.StringValue(ruleType)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -343,54 +605,58 @@ func (r *NotificationRuleResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Make HTTP request to get notification rule
-	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v2/notificationRules/%s", r.serverURL, data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Accept", "application/json")
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Unable to read notification rule: %s", err))
-		return
-	}
-	defer httpResp.Body.Close()
+	ctx, span := tracing.Start(ctx, "influxdb_notification_rule", "Read", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
 
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddWarning("[READ STAGE] Resource Not Found", "Notification rule not found, removing from state")
-		resp.State.RemoveResource(ctx)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+	// Refreshes are served from one listing of the org's rules
+	body, err := r.batch.Get(ctx, "notificationRules", data.OrgID.ValueString(), data.ID.ValueString())
+	if removeIfNotFound(ctx, err, resp) {
 		return
 	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("[READ STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	if err != nil {
+		resp.Diagnostics.AddError("[READ STAGE] API Error", fmt.Sprintf("Unable to read notification rule: %s", err))
 		return
 	}
 
-	var rule NotificationRuleResponse
-	if err := json.Unmarshal(body, &rule); err != nil {
+	decodedRule, err := decodeNotificationRule(body)
+	if err != nil {
 		resp.Diagnostics.AddError("Deserialization Error", fmt.Sprintf("Unable to parse notification rule response: %s", err))
 		return
 	}
+	rule, ruleType, messageTemplate, channel := notificationRuleFields(decodedRule)
 
 	// Update data with response
-	data.ID = types.StringValue(rule.ID) // Ensure ID is preserved
+	data.ID = types.StringPointerValue(rule.Id) // Ensure ID is preserved
+	data.CreatedAt = formatTimestamp(rule.CreatedAt)
+	data.UpdatedAt = formatTimestamp(rule.UpdatedAt)
+	data.LatestCompleted = formatTimestamp(rule.LatestCompleted)
+	data.OrgID = types.StringValue(rule.OrgID)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Only unknown after import
+		data.Org = types.StringValue(orgNameOrID(ctx, r.orgs, rule.OrgID))
+	}
 	data.Name = types.StringValue(rule.Name)
 	if rule.Description != nil {
 		data.Description = types.StringValue(*rule.Description)
+	} else {
+		data.Description = types.StringNull()
 	}
-	data.Status = types.StringValue(rule.Status)
-	data.Type = types.StringValue(rule.Type)
+	data.Status = types.StringValue(string(rule.Status))
+	data.Type = types.StringValue(ruleType)
 	data.EndpointID = types.StringValue(rule.EndpointID)
 
 	if rule.Every != nil {
@@ -398,35 +664,34 @@ func (r *NotificationRuleResource) Read(ctx context.Context, req resource.ReadRe
 	}
 	if rule.Offset != nil {
 		data.Offset = types.StringValue(*rule.Offset)
+	} else {
+		data.Offset = types.StringValue("0s")
+	}
+	if messageTemplate != nil && *messageTemplate != "" {
+		// Keep the configured formatting unless the template changed beyond whitespace
+		if data.MessageTemplate.IsNull() || !normalizationIgnored(data.IgnoreNormalization, r.ignoreNormalization) ||
+			common.NormalizeFlux(data.MessageTemplate.ValueString()) != common.NormalizeFlux(*messageTemplate) {
+			data.MessageTemplate = types.StringValue(*messageTemplate)
+		}
+	} else {
+		data.MessageTemplate = types.StringNull()
+	}
+	if channel != nil && *channel != "" {
+		data.Channel = types.StringValue(*channel)
+	} else {
+		data.Channel = types.StringNull()
 	}
 
-	// Convert status rules
-	if len(rule.StatusRules) > 0 {
-		statusRules := make([]StatusRuleModel, len(rule.StatusRules))
-		for i, rule := range rule.StatusRules {
-			statusRules[i] = StatusRuleModel{
-				CurrentLevel: types.StringValue(rule.CurrentLevel),
-			}
-			if rule.PreviousLevel != "" {
-				statusRules[i].PreviousLevel = types.StringValue(rule.PreviousLevel)
-			}
-		}
-		data.StatusRules = statusRules
-	}
-
-	// Convert tag rules
-	if len(rule.TagRules) > 0 {
-		tagRules := make([]TagRuleModel, len(rule.TagRules))
-		for i, rule := range rule.TagRules {
-			tagRules[i] = TagRuleModel{
-				Key:      types.StringValue(rule.Key),
-				Value:    types.StringValue(rule.Value),
-				Operator: types.StringValue(rule.Operator),
-			}
-		}
-		data.TagRules = tagRules
+	// Always reconcile both rule lists, so rules removed outside of Terraform show up as drift
+	data.StatusRules = statusRuleModels(rule.StatusRules)
+	data.TagRules = tagRuleModels(rule.TagRules)
+
+	// skip_destroy only exists in Terraform, e.g. an import starts without it
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
 	}
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -443,143 +708,91 @@ func (r *NotificationRuleResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	// Use ID from current state, not from plan
-	if state.ID.IsNull() || state.ID.ValueString() == "" {
-		resp.Diagnostics.AddError("[UPDATE STAGE] Missing ID", "Cannot update notification rule without an ID from current state")
+	updateTimeout, timeoutDiags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Use the ID from the state
-	data.ID = state.ID
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	org := r.org
-	if !data.Org.IsNull() {
-		org = data.Org.ValueString()
-	}
+	ctx, span := tracing.Start(ctx, "influxdb_notification_rule", "Update", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
 
-	// Get org ID
-	orgAPI := r.client.OrganizationsAPI()
-	orgObj, err := orgAPI.FindOrganizationByName(ctx, org)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find organization %s, got error: %s", org, err))
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get the current user ID as the owner
-	userAPI := r.client.UsersAPI()
-	currentUser, err := userAPI.Me(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("[UPDATE STAGE] User Error", fmt.Sprintf("Unable to get current user: %s", err))
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "update", "influxdb_notification_rule") {
 		return
 	}
 
-	// Prepare request for PUT update (requires ID)
-	ruleReq := NotificationRuleUpdateRequest{
-		ID:          data.ID.ValueString(),
-		Name:        data.Name.ValueString(),
-		Status:      data.Status.ValueString(),
-		Type:        data.Type.ValueString(),
-		EndpointID:  data.EndpointID.ValueString(),
-		OwnerID:     *currentUser.Id,
-		Every:       data.Every.ValueString(),
-		OrgID:       *orgObj.Id,
-		StatusRules: []StatusRule{}, // Will be populated below if provided
-	}
-
-	// Set offset from model
-	offset := data.Offset.ValueString()
-	ruleReq.Offset = &offset
-
-	if !data.Description.IsNull() {
-		desc := data.Description.ValueString()
-		ruleReq.Description = &desc
+	// Use ID from current state, not from plan
+	if state.ID.IsNull() || state.ID.ValueString() == "" {
+		resp.Diagnostics.AddError("[UPDATE STAGE] Missing ID", "Cannot update notification rule without an ID from current state")
+		return
 	}
 
-	if !data.Offset.IsNull() {
-		offset := data.Offset.ValueString()
-		ruleReq.Offset = &offset
-	}
+	// Use the ID from the state
+	data.ID = state.ID
 
-	// Convert status rules
-	if len(data.StatusRules) > 0 {
-		statusRules := make([]StatusRule, len(data.StatusRules))
-		for i, rule := range data.StatusRules {
-			statusRules[i] = StatusRule{
-				CurrentLevel: rule.CurrentLevel.ValueString(),
-			}
-			if !rule.PreviousLevel.IsNull() {
-				statusRules[i].PreviousLevel = rule.PreviousLevel.ValueString()
-			}
-		}
-		ruleReq.StatusRules = statusRules
-	}
-
-	// Convert tag rules
-	if len(data.TagRules) > 0 {
-		tagRules := make([]TagRule, len(data.TagRules))
-		for i, rule := range data.TagRules {
-			tagRules[i] = TagRule{
-				Key:      rule.Key.ValueString(),
-				Value:    rule.Value.ValueString(),
-				Operator: rule.Operator.ValueString(),
-			}
+	// A replaced endpoint was unknown during the plan
+	if !data.Type.Equal(state.Type) || !data.EndpointID.Equal(state.EndpointID) {
+		r.checkEndpointType(ctx, &resp.Diagnostics, data.Type.ValueString(), data.EndpointID.ValueString())
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		ruleReq.TagRules = tagRules
 	}
 
-	// Make HTTP request
-	jsonData, err := json.Marshal(ruleReq)
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
 	if err != nil {
-		resp.Diagnostics.AddError("Serialization Error", fmt.Sprintf("Unable to serialize notification rule: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
 		return
 	}
 
-	updateURL := fmt.Sprintf("%s/api/v2/notificationRules/%s", r.serverURL, data.ID.ValueString())
-	httpReq, err := http.NewRequest("PUT", updateURL, bytes.NewBuffer(jsonData))
+	// Get the current user ID as the owner
+	userAPI := r.client.UsersAPI()
+	currentUser, err := userAPI.Me(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create HTTP request for URL %s: %s", updateURL, err))
+		resp.Diagnostics.AddError("[UPDATE STAGE] User Error", fmt.Sprintf("Unable to get current user: %s", err))
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-
-	// Use default client like our working curl command
-	httpResp, err := http.DefaultClient.Do(httpReq)
+	// Prepare request for PUT update (requires ID)
+	ruleReq, err := buildNotificationRule(&data, *currentUser.Id, orgID)
 	if err != nil {
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Unable to update notification rule: %s", err))
+		resp.Diagnostics.AddError("[UPDATE STAGE] Invalid Configuration", err.Error())
 		return
 	}
-	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
+	body, err := r.api.Do(ctx, http.MethodPut, "notificationRules/"+data.ID.ValueString(), ruleReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Response Error", fmt.Sprintf("Unable to read response body: %s", err))
+		addAPIError(&resp.Diagnostics, "[UPDATE STAGE] API Error", fmt.Sprintf("Unable to update notification rule: %s", err), err, ruleErrorHints)
 		return
 	}
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("[UPDATE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d for URL %s with request body: %s\nResponse: %s", httpResp.StatusCode, updateURL, string(jsonData), string(body)))
-		return
-	}
-
-	var rule NotificationRuleResponse
-	if err := json.Unmarshal(body, &rule); err != nil {
+	decodedRule, err := decodeNotificationRule(body)
+	if err != nil {
 		resp.Diagnostics.AddError("[UPDATE STAGE] Deserialization Error", fmt.Sprintf("Unable to parse notification rule response: %s\nResponse body: %s", err, string(body)))
 		return
 	}
+	rule, ruleType, _, _ := notificationRuleFields(decodedRule)
 
 	// Update data with response - preserve all current values and update what changed
 	data.Name = types.StringValue(rule.Name)
-	data.Status = types.StringValue(rule.Status)
-	data.Type = types.StringValue(rule.Type)
-	data.Org = types.StringValue(org) // Ensure org is properly set
+	data.Status = types.StringValue(string(rule.Status))
+	data.Type = types.StringValue(ruleType)
+	data.OrgID = types.StringValue(orgID)
+	// latest_completed keeps its planned state value and is refreshed on the next read
+	data.UpdatedAt = formatTimestamp(rule.UpdatedAt)
 	if rule.Every != nil {
 		data.Every = types.StringValue(*rule.Every)
 	}
 	// Keep other fields as they are since they shouldn't change during update
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -592,29 +805,65 @@ func (r *NotificationRuleResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	// Make HTTP request to delete notification rule
-	httpReq, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v2/notificationRules/%s", r.serverURL, data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
+	// The notification rule is kept in InfluxDB, Terraform drops it from the state
+	if data.SkipDestroy.ValueBool() {
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Token "+r.authToken)
+	deleteTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	httpResp, err := r.httpClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Unable to delete notification rule: %s", err))
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_notification_rule", "Delete", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(httpResp.Body)
-		resp.Diagnostics.AddError("[DELETE STAGE] API Error", fmt.Sprintf("InfluxDB API returned status %d: %s", httpResp.StatusCode, string(body)))
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "delete", "influxdb_notification_rule") {
+		return
+	}
+
+	// A rule which is already gone needs no deletion
+	_, err := r.api.Do(ctx, http.MethodDelete, "notificationRules/"+data.ID.ValueString(), nil)
+	if err != nil && !errors.Is(err, apiclient.ErrNotFound) {
+		resp.Diagnostics.AddError("[DELETE STAGE] API Error", fmt.Sprintf("Unable to delete notification rule: %s", err))
 		return
 	}
 }
 
+// ImportState imports a rule by ID or by `org/name`, where org is an organization
+// name or ID
 func (r *NotificationRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importByIDOrName(ctx, req, resp, r.orgs, r.org, r.url, "notification rule", func(ctx context.Context, orgID, name string) ([]string, error) {
+		return listIDsByName(ctx, r.api, "notificationRules", orgID, name)
+	})
+}
+
+func (r *NotificationRuleResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listConfigSchema()
+}
+
+func (r *NotificationRuleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.unconfigured.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(r.unconfigured)
+		return
+	}
+
+	listCollection(ctx, req, stream, r, r.api, r.orgs, r.org, r.url, "notificationRules", func(member listMember) bool {
+		_, ok := supportedEndpointTypes[member.Type]
+		return ok
+	})
 }
@@ -0,0 +1,175 @@
+package resources
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// slackRuleAttributes returns the attributes of a planned Slack notification rule
+func slackRuleAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":             "critical",
+		"org_id":           testOrgID,
+		"status":           "active",
+		"type":             "slack",
+		"endpoint_id":      "0000000000000002",
+		"every":            "1m",
+		"offset":           "0s",
+		"channel":          "#alerts",
+		"message_template": "${r._message}",
+		"status_rules": []StatusRuleModel{{
+			CurrentLevel:  types.StringValue("CRIT"),
+			PreviousLevel: types.StringValue("OK"),
+		}},
+		"tag_rules": []TagRuleModel{{
+			Key:      types.StringValue("env"),
+			Value:    types.StringValue("production"),
+			Operator: types.StringValue("equal"),
+		}},
+	}
+}
+
+func TestNotificationRuleCreatePayload(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "me", http.StatusOK, `{"id":"00000000000000ff","name":"admin"}`)
+	api.handle(http.MethodPost, "notificationRules", echoCreated("0000000000000003"))
+
+	r := NewNotificationRuleResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, slackRuleAttributes())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+
+	request := api.lastRequest(http.MethodPost, "notificationRules")
+	if got := request.Header.Get("Authorization"); got != "Token "+testToken {
+		t.Errorf("got Authorization %q", got)
+	}
+
+	body := request.JSON(t)
+	want := map[string]interface{}{
+		"name":            "critical",
+		"orgID":           testOrgID,
+		"ownerID":         "00000000000000ff",
+		"status":          "active",
+		"type":            "slack",
+		"endpointID":      "0000000000000002",
+		"every":           "1m",
+		"offset":          "0s",
+		"channel":         "#alerts",
+		"messageTemplate": "${r._message}",
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("got %s %v, want %v", key, body[key], value)
+		}
+	}
+
+	statusRules, _ := body["statusRules"].([]interface{})
+	if len(statusRules) != 1 {
+		t.Fatalf("got statusRules %v", body["statusRules"])
+	}
+	if rule := statusRules[0].(map[string]interface{}); rule["currentLevel"] != "CRIT" || rule["previousLevel"] != "OK" {
+		t.Errorf("got status rule %v", rule)
+	}
+
+	tagRules, _ := body["tagRules"].([]interface{})
+	if len(tagRules) != 1 {
+		t.Fatalf("got tagRules %v", body["tagRules"])
+	}
+	if rule := tagRules[0].(map[string]interface{}); rule["key"] != "env" || rule["value"] != "production" || rule["operator"] != "equal" {
+		t.Errorf("got tag rule %v", rule)
+	}
+
+	if id := stateString(t, resp.State, "id"); id.ValueString() != "0000000000000003" {
+		t.Errorf("got id %s", id)
+	}
+}
+
+func TestNotificationRuleCreateWithoutStatusRules(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "me", http.StatusOK, `{"id":"00000000000000ff","name":"admin"}`)
+	api.handle(http.MethodPost, "notificationRules", echoCreated("0000000000000003"))
+
+	attributes := slackRuleAttributes()
+	delete(attributes, "status_rules")
+	delete(attributes, "tag_rules")
+
+	r := NewNotificationRuleResource()
+	configure(t, r, api.providerData(0))
+	if resp := create(t, r, attributes); resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+
+	// The API rejects null status rules
+	body := api.lastRequest(http.MethodPost, "notificationRules").JSON(t)
+	if statusRules, ok := body["statusRules"].([]interface{}); !ok || len(statusRules) != 0 {
+		t.Errorf("got statusRules %v, want an empty list", body["statusRules"])
+	}
+}
+
+func TestNotificationRuleCreateTypeMismatch(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "me", http.StatusOK, `{"id":"00000000000000ff","name":"admin"}`)
+	api.respond(http.MethodGet, "notificationEndpoints/0000000000000002", http.StatusOK, `{"id":"0000000000000002","type":"http"}`)
+	api.handle(http.MethodPost, "notificationRules", echoCreated("0000000000000003"))
+
+	r := NewNotificationRuleResource()
+	configure(t, r, api.providerData(0))
+	resp := create(t, r, slackRuleAttributes())
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Notification Rule Type Mismatch" {
+		t.Fatalf("got diagnostics %v, want a type mismatch", resp.Diagnostics)
+	}
+	if requests := api.requestsTo(http.MethodPost, "notificationRules"); len(requests) != 0 {
+		t.Errorf("got %d rule requests, want none", len(requests))
+	}
+}
+
+func TestNotificationRuleUpdate(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "me", http.StatusOK, `{"id":"00000000000000ff","name":"admin"}`)
+	api.handle(http.MethodPut, "notificationRules/0000000000000003", echo(http.StatusOK, "0000000000000003"))
+
+	prior := slackRuleAttributes()
+	prior["id"] = "0000000000000003"
+	planned := slackRuleAttributes()
+	planned["id"] = types.StringUnknown()
+	planned["every"] = "5m"
+
+	r := NewNotificationRuleResource()
+	configure(t, r, api.providerData(0))
+	resp := update(t, r, prior, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %v", resp.Diagnostics)
+	}
+
+	// PUT replaces the whole rule
+	body := api.lastRequest(http.MethodPut, "notificationRules/0000000000000003").JSON(t)
+	if body["every"] != "5m" || body["id"] != "0000000000000003" || body["endpointID"] != "0000000000000002" || body["channel"] != "#alerts" {
+		t.Errorf("got body %v", body)
+	}
+}
+
+func TestNotificationRuleUpdateError(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodGet, "me", http.StatusOK, `{"id":"00000000000000ff","name":"admin"}`)
+	api.handle(http.MethodPut, "notificationRules/0000000000000003", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusBadRequest, "invalid", "endpoint 0000000000000002 does not exist")
+	})
+
+	prior := slackRuleAttributes()
+	prior["id"] = "0000000000000003"
+	planned := slackRuleAttributes()
+	planned["id"] = "0000000000000003"
+	planned["every"] = "5m"
+
+	r := NewNotificationRuleResource()
+	configure(t, r, api.providerData(0))
+	resp := update(t, r, prior, planned)
+
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != "[UPDATE STAGE] API Error" {
+		t.Errorf("got diagnostics %v", resp.Diagnostics)
+	}
+}
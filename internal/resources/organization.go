@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// resolveOrgID determines the organization ID to use for a resource. An explicit
+// org_id wins, then the org attribute and finally the provider default. Values
+// that already are IDs are used as-is, skipping the name lookup.
+func resolveOrgID(ctx context.Context, orgs *common.OrgCache, orgID, org types.String, defaultOrg string) (string, error) {
+	if !orgID.IsNull() && !orgID.IsUnknown() && orgID.ValueString() != "" {
+		return orgID.ValueString(), nil
+	}
+
+	orgName := defaultOrg
+	if !org.IsNull() && !org.IsUnknown() {
+		orgName = org.ValueString()
+	}
+
+	id, err := orgs.Resolve(ctx, orgName)
+	if err != nil {
+		return "", fmt.Errorf("unable to find organization '%s': %w", orgName, err)
+	}
+
+	return id, nil
+}
+
+// orgNameOrID returns the name of the organization with the given ID, falling back
+// to the ID itself when the token is not allowed to read organizations
+func orgNameOrID(ctx context.Context, orgs *common.OrgCache, orgID string) string {
+	name, err := orgs.NameByID(ctx, orgID)
+	if err != nil {
+		return orgID
+	}
+
+	return name
+}
+
+// requireReplaceOnOrgChange replaces a resource whose org or org_id points to
+// another organization, the API cannot move objects between organizations.
+// Switching between the name and the ID of the same organization is not a move.
+func requireReplaceOnOrgChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, orgs *common.OrgCache, defaultOrg string, unconfigured diag.Diagnostics) {
+	// Nothing to compare on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planOrg, planOrgID, stateOrg, stateOrgID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("org"), &planOrg)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("org_id"), &planOrgID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("org"), &stateOrg)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("org_id"), &stateOrgID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planOrgID.IsUnknown() && !planOrgID.Equal(stateOrgID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("org_id"))
+		return
+	}
+
+	// The organization is resolved on apply when it is not known yet or the
+	// provider is not configured, e.g. in validate
+	if planOrg.IsUnknown() || planOrg.Equal(stateOrg) || unconfigured.HasError() {
+		return
+	}
+
+	orgID, err := resolveOrgID(ctx, orgs, types.StringNull(), planOrg, defaultOrg)
+	if err != nil {
+		resp.Diagnostics.AddError("Plan - Client Error", fmt.Sprintf("Unable to resolve organization: %s", err))
+		return
+	}
+	if orgID != stateOrgID.ValueString() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("org"))
+	}
+}
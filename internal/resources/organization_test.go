@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequireReplaceOnOrgChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		org         interface{}
+		orgID       interface{}
+		wantReplace []path.Path
+	}{
+		{name: "unchanged", org: "acme", orgID: testOrgID},
+		{name: "unknown", org: types.StringUnknown(), orgID: types.StringUnknown()},
+		{name: "name to ID of the same organization", org: testOrgID, orgID: types.StringUnknown()},
+		{name: "other organization name", org: "other", orgID: types.StringUnknown(), wantReplace: []path.Path{path.Root("org")}},
+		{name: "other organization ID", org: "acme", orgID: "0000000000000bbb", wantReplace: []path.Path{path.Root("org_id")}},
+	}
+
+	resources := map[string]func() resource.Resource{
+		"bucket":   NewBucketResource,
+		"check":    NewCheckResource,
+		"endpoint": NewNotificationEndpointResource,
+		"rule":     NewNotificationRuleResource,
+		"task":     NewTaskResource,
+	}
+
+	for name, newResource := range resources {
+		for _, test := range tests {
+			t.Run(name+"/"+test.name, func(t *testing.T) {
+				api := newMockAPI(t)
+				api.handle(http.MethodGet, "orgs", func(w http.ResponseWriter, r *http.Request) {
+					id := map[string]string{"acme": testOrgID, "other": "0000000000000bbb"}[r.URL.Query().Get("org")]
+					fmt.Fprintf(w, `{"orgs":[{"id":%q,"name":%q}]}`, id, r.URL.Query().Get("org"))
+				})
+
+				r := newResource()
+				configure(t, r, api.providerData(0))
+				prior := map[string]interface{}{"id": "0000000000000001", "name": "cpu", "org": "acme", "org_id": testOrgID}
+				planned := map[string]interface{}{"id": "0000000000000001", "name": "cpu", "org": test.org, "org_id": test.orgID}
+				resp := modifyPlan(t, r, prior, planned)
+
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("ModifyPlan failed: %v", resp.Diagnostics)
+				}
+				if fmt.Sprint(resp.RequiresReplace) != fmt.Sprint(test.wantReplace) {
+					t.Errorf("got replacement of %v, want %v", resp.RequiresReplace, test.wantReplace)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+const (
+	testToken = "test-token"
+	testOrgID = "0000000000000aaa"
+)
+
+// mockRequest is a request received by mockAPI
+type mockRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+// JSON decodes the request body into a generic value for assertions
+func (r mockRequest) JSON(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(r.Body), &body); err != nil {
+		t.Fatalf("%s %s sent no JSON body: %s", r.Method, r.Path, err)
+	}
+	return body
+}
+
+// mockAPI is an httptest server standing in for the InfluxDB v2 API. Requests are
+// answered by the handler registered for their method and path below /api/v2/ and
+// recorded for assertions. Requests without handler get a 404 like unknown
+// objects in InfluxDB.
+type mockAPI struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []mockRequest
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	t.Helper()
+
+	m := &mockAPI{t: t, handlers: map[string]http.HandlerFunc{}}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serve))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockAPI) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	apiPath := strings.TrimPrefix(r.URL.Path, "/api/v2/")
+
+	m.mu.Lock()
+	m.requests = append(m.requests, mockRequest{
+		Method: r.Method,
+		Path:   apiPath,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   string(body),
+	})
+	handler := m.handlers[r.Method+" "+apiPath]
+	m.mu.Unlock()
+
+	// Handlers may read the body again
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	w.Header().Set("Content-Type", "application/json")
+	if handler == nil {
+		writeAPIError(w, http.StatusNotFound, "not found", apiPath+" not found")
+		return
+	}
+	handler(w, r)
+}
+
+// handle registers the handler answering requests with method to the API path
+// relative to /api/v2/, e.g. "checks/<id>"
+func (m *mockAPI) handle(method, apiPath string, handler http.HandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[method+" "+apiPath] = handler
+}
+
+// respond registers a fixed response
+func (m *mockAPI) respond(method, apiPath string, status int, body string) {
+	m.handle(method, apiPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	})
+}
+
+// requestsTo returns the recorded requests with method to the API path
+func (m *mockAPI) requestsTo(method, apiPath string) []mockRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var matching []mockRequest
+	for _, request := range m.requests {
+		if request.Method == method && request.Path == apiPath {
+			matching = append(matching, request)
+		}
+	}
+	return matching
+}
+
+// lastRequest returns the only or last request with method to the API path
+func (m *mockAPI) lastRequest(method, apiPath string) mockRequest {
+	m.t.Helper()
+
+	requests := m.requestsTo(method, apiPath)
+	if len(requests) == 0 {
+		m.t.Fatalf("no %s request to %s", method, apiPath)
+	}
+	return requests[len(requests)-1]
+}
+
+// providerData returns provider data sending requests to the mock through the
+// retrying client of the provider, with delays short enough for tests
+func (m *mockAPI) providerData(maxRetries int) *common.ProviderData {
+	httpClient := common.WithRetries(m.server.Client(), maxRetries, time.Millisecond)
+	client := influxdb2.NewClientWithOptions(m.server.URL, testToken, influxdb2.DefaultOptions().SetHTTPClient(httpClient))
+	m.t.Cleanup(client.Close)
+
+	api := apiclient.New(client)
+	return &common.ProviderData{
+		Client:     client,
+		API:        api,
+		Batch:      apiclient.NewBatch(api, false),
+		Orgs:       common.NewOrgCache(client),
+		HTTPClient: m.server.Client(),
+		Token:      testToken,
+		URL:        m.server.URL,
+	}
+}
+
+// writeAPIError writes an error body as returned by InfluxDB
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": code, "message": message})
+}
+
+// echoCreated answers with the request body extended by an ID, as InfluxDB does
+// for created objects
+func echoCreated(id string) http.HandlerFunc {
+	return echo(http.StatusCreated, id)
+}
+
+// echo answers with the request body extended by an ID
+func echo(status int, id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+			return
+		}
+		body["id"] = id
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// configure configures a resource with the provider data
+func configure(t *testing.T, r resource.Resource, data *common.ProviderData) {
+	t.Helper()
+
+	resp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{ProviderData: data}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure failed: %v", resp.Diagnostics)
+	}
+}
+
+// newState returns a state of the resource with the given attribute values, all
+// other attributes are null. Values are Go or framework values, e.g. strings or
+// slices of the nested models.
+func newState(t *testing.T, r resource.Resource, attributes map[string]interface{}) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema failed: %v", schemaResp.Diagnostics)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unable to set %s: %v", name, diags)
+		}
+	}
+	return state
+}
+
+// plan returns a plan and configuration with the values of state
+func plan(state tfsdk.State) (tfsdk.Plan, tfsdk.Config) {
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}, tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+}
+
+// create runs Create of the resource with a plan of the given attributes
+func create(t *testing.T, r resource.Resource, attributes map[string]interface{}) *resource.CreateResponse {
+	t.Helper()
+
+	state := newState(t, r, attributes)
+	plannedState, config := plan(state)
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plannedState, Config: config}, resp)
+	return resp
+}
+
+// read runs Read of the resource with a state of the given attributes
+func read(t *testing.T, r resource.Resource, attributes map[string]interface{}) *resource.ReadResponse {
+	t.Helper()
+
+	state := newState(t, r, attributes)
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+// update runs Update of the resource from a state to a plan of the given attributes
+func update(t *testing.T, r resource.Resource, prior, planned map[string]interface{}) *resource.UpdateResponse {
+	t.Helper()
+
+	state := newState(t, r, prior)
+	plannedState, config := plan(newState(t, r, planned))
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: plannedState.Raw.Copy()}}
+	r.Update(context.Background(), resource.UpdateRequest{State: state, Plan: plannedState, Config: config}, resp)
+	return resp
+}
+
+// modifyPlan runs ModifyPlan of the resource from a state to a plan of the given
+// attributes
+func modifyPlan(t *testing.T, r resource.Resource, prior, planned map[string]interface{}) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	state := newState(t, r, prior)
+	plannedState, config := plan(newState(t, r, planned))
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: plannedState.Schema, Raw: plannedState.Raw.Copy()}}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), resource.ModifyPlanRequest{State: state, Plan: plannedState, Config: config}, resp)
+	return resp
+}
+
+// stateString returns the value of a string attribute of a state
+func stateString(t *testing.T, state tfsdk.State, name string) types.String {
+	t.Helper()
+
+	var value types.String
+	if diags := state.GetAttribute(context.Background(), path.Root(name), &value); diags.HasError() {
+		t.Fatalf("unable to get %s: %v", name, diags)
+	}
+	return value
+}
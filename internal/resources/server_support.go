@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+// requireManagementAPI fails the plan of a new resource when the server cannot
+// manage it, e.g. InfluxDB 1.8 with its v2 compatibility API, instead of failing
+// the apply with a 404. Servers which cannot be detected are assumed to support it.
+func requireManagementAPI(ctx context.Context, api *apiclient.Client, unconfigured diag.Diagnostics, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, typeName string) {
+	// Only creates are checked, existing resources prove the support
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || unconfigured.HasError() {
+		return
+	}
+
+	server, err := api.Server(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to detect InfluxDB server", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if !server.SupportsManagementAPI() {
+		resp.Diagnostics.AddError(
+			"Plan - Unsupported Server",
+			fmt.Sprintf("%s requires InfluxDB 2.x or InfluxDB Cloud, but the server is InfluxDB %s %s, which only supports querying and writing through the v2 API.", typeName, server.Flavor, server.Version),
+		)
+	}
+}
@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// compatibleStateUpgrader upgrades state of a prior schema version whose stored
+// JSON still decodes into the current schema, e.g. when a list became a set.
+// Attributes removed since are dropped and attributes added since are null.
+// Schema changes that need the values themselves converted, such as a number
+// becoming a string, need a dedicated upgrader with a PriorSchema instead.
+func compatibleStateUpgrader() resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil {
+				return
+			}
+
+			value, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Upgrade State - Decode Error", fmt.Sprintf("Unable to decode prior state: %s", err))
+				return
+			}
+
+			resp.State.Raw = value
+		},
+	}
+}
@@ -2,140 +2,77 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
 	"github.com/xing/terraform-provider-influxdb/internal/common"
+	"github.com/xing/terraform-provider-influxdb/internal/tracing"
+	"github.com/xing/terraform-provider-influxdb/internal/validators"
 )
 
-// fluxNormalizationModifier normalizes flux queries for comparison
-type fluxNormalizationModifier struct{}
-
-func (m fluxNormalizationModifier) Description(ctx context.Context) string {
-	return "Normalizes flux whitespace for comparison"
-}
-
-func (m fluxNormalizationModifier) MarkdownDescription(ctx context.Context) string {
-	return "Normalizes flux whitespace for comparison"
-}
-
-// normalizeFluxForComparison removes all leading/trailing whitespace and normalizes line breaks
-func normalizeFluxForComparison(flux string) string {
-	lines := strings.Split(flux, "\n")
-	var normalizedLines []string
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" {
-			normalizedLines = append(normalizedLines, trimmed)
-		}
-	}
-
-	return strings.Join(normalizedLines, "\n")
-}
-
-func (m fluxNormalizationModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// If either config or state is null/unknown, don't modify
-	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
-		return
-	}
-
-	// Normalize both values for comparison
-	normalizedConfig := normalizeFluxForComparison(req.ConfigValue.ValueString())
-	normalizedState := normalizeFluxForComparison(req.StateValue.ValueString())
-
-	// If normalized values are equal, keep the state value to prevent drift
-	if normalizedConfig == normalizedState {
-		resp.PlanValue = req.StateValue
-	}
-}
-
-// updatedAtConditionalModifier preserves updated_at when no changes occur
-type updatedAtConditionalModifier struct{}
-
-func (m updatedAtConditionalModifier) Description(ctx context.Context) string {
-	return "Preserves updated_at value when no other fields change"
-}
-
-func (m updatedAtConditionalModifier) MarkdownDescription(ctx context.Context) string {
-	return "Preserves updated_at value when no other fields change"
-}
-
-func (m updatedAtConditionalModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// If this is a create operation, let it be computed
-	if req.State.Raw.IsNull() {
-		return
-	}
-
-	// Get the complete state and plan data to compare all fields
-	var stateData, planData TaskResourceModel
-	diags := req.State.Get(ctx, &stateData)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	diags = req.Plan.Get(ctx, &planData)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	// Check if any fields other than updated_at are changing
-	fieldsChanged := false
-	if !stateData.Name.Equal(planData.Name) ||
-		!stateData.Description.Equal(planData.Description) ||
-		!stateData.Cron.Equal(planData.Cron) ||
-		!stateData.Every.Equal(planData.Every) ||
-		!stateData.Offset.Equal(planData.Offset) ||
-		!stateData.Status.Equal(planData.Status) ||
-		normalizeFluxForComparison(stateData.Flux.ValueString()) != normalizeFluxForComparison(planData.Flux.ValueString()) {
-		fieldsChanged = true
-	}
-
-	// If no other fields changed, keep the current updated_at value
-	if !fieldsChanged {
-		resp.PlanValue = req.StateValue
-	}
-	// Otherwise, let it be computed (don't set resp.PlanValue)
-}
-
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TaskResource{}
 var _ resource.ResourceWithImportState = &TaskResource{}
+var _ resource.ResourceWithIdentity = &TaskResource{}
+var _ list.ListResourceWithConfigure = &TaskResource{}
+var _ resource.ResourceWithConfigValidators = &TaskResource{}
+var _ resource.ResourceWithModifyPlan = &TaskResource{}
 
 func NewTaskResource() resource.Resource {
 	return &TaskResource{}
 }
 
+func NewTaskListResource() list.ListResource {
+	return &TaskResource{}
+}
+
 // TaskResource defines the resource implementation.
 type TaskResource struct {
-	client influxdb2.Client
-	org    string
+	client              influxdb2.Client
+	org                 string
+	url                 string
+	orgs                *common.OrgCache
+	api                 *apiclient.Client
+	batch               *apiclient.Batch
+	readOnly            bool
+	ignoreNormalization bool
+	unconfigured        diag.Diagnostics
 }
 
 // TaskResourceModel describes the resource data model.
 type TaskResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Org         types.String `tfsdk:"org"`
-	Description types.String `tfsdk:"description"`
-	Flux        types.String `tfsdk:"flux"`
-	Status      types.String `tfsdk:"status"`
-	Every       types.String `tfsdk:"every"`
-	Cron        types.String `tfsdk:"cron"`
-	Offset      types.String `tfsdk:"offset"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	UpdatedAt   types.String `tfsdk:"updated_at"`
+	ID                  types.String   `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	Org                 types.String   `tfsdk:"org"`
+	OrgID               types.String   `tfsdk:"org_id"`
+	Description         types.String   `tfsdk:"description"`
+	Flux                types.String   `tfsdk:"flux"`
+	Status              types.String   `tfsdk:"status"`
+	Every               types.String   `tfsdk:"every"`
+	Cron                types.String   `tfsdk:"cron"`
+	Offset              types.String   `tfsdk:"offset"`
+	CreatedAt           types.String   `tfsdk:"created_at"`
+	UpdatedAt           types.String   `tfsdk:"updated_at"`
+	SkipDestroy         types.Bool     `tfsdk:"skip_destroy"`
+	IgnoreNormalization types.Bool     `tfsdk:"ignore_normalization"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *TaskResource) stripOptionTaskLine(flux string) string {
@@ -192,7 +129,15 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"org": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default.",
+				MarkdownDescription: "Organization name or ID. If not provided, uses the provider default. Moving the task to another organization forces a new task to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Organization ID. Can be used instead of `org` to skip the organization name lookup, e.g. with tokens that cannot read organizations. Takes precedence over `org` when both are set, e.g. in configuration generated on import.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -204,26 +149,36 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"flux": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Flux script to execute",
-				PlanModifiers: []planmodifier.String{
-					fluxNormalizationModifier{},
-				},
 			},
 			"status": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Task status (active or inactive). Defaults to active.",
+				Default:             stringdefault.StaticString(string(domain.TaskStatusTypeActive)),
+				MarkdownDescription: "Task status (active or inactive). Defaults to `active`.",
+				Validators: []validator.String{
+					validators.Status(),
+				},
 			},
 			"every": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Duration-based schedule (e.g., '1h', '30m'). Either 'every' or 'cron' must be specified.",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
 			},
 			"cron": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Cron-based schedule (e.g., '0 */1 * * *'). Either 'every' or 'cron' must be specified.",
+				Validators: []validator.String{
+					validators.Cron(),
+				},
 			},
 			"offset": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Optional time offset for scheduling",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
@@ -235,14 +190,74 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"updated_at": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Task last update timestamp",
-				PlanModifiers: []planmodifier.String{
-					updatedAtConditionalModifier{},
-				},
 			},
+			"ignore_normalization": ignoreNormalizationAttribute(),
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only remove the task from the Terraform state on destroy and keep it in InfluxDB. Only takes effect once applied, before the destroy. Defaults to `false`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
+func (r *TaskResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema()
+}
+
+func (r *TaskResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("every"), path.MatchRoot("cron")),
+	}
+}
+
+// ModifyPlan rejects new tasks on servers without tasks, ignores changes InfluxDB
+// normalizes away, replaces tasks moved to another organization and keeps
+// updated_at unless the task itself changes
+func (r *TaskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireManagementAPI(ctx, r.api, r.unconfigured, req, resp, "influxdb_task")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignoreNormalizedChanges(ctx, req, resp, r.ignoreNormalization, map[string]normalizer{
+		"flux":   common.NormalizeFlux,
+		"every":  normalizeDuration,
+		"offset": normalizeDuration,
+	})
+
+	requireReplaceOnOrgChange(ctx, req, resp, r.orgs, r.org, r.unconfigured)
+
+	// Nothing to keep on create and destroy
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var stateData, planData TaskResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if any fields other than updated_at are changing
+	if stateData.Name.Equal(planData.Name) &&
+		stateData.Description.Equal(planData.Description) &&
+		stateData.Cron.Equal(planData.Cron) &&
+		stateData.Every.Equal(planData.Every) &&
+		stateData.Offset.Equal(planData.Offset) &&
+		stateData.Status.Equal(planData.Status) &&
+		stateData.Flux.Equal(planData.Flux) &&
+		stateData.Org.Equal(planData.Org) &&
+		stateData.OrgID.Equal(planData.OrgID) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("updated_at"), stateData.UpdatedAt)...)
+	}
+}
+
 func (r *TaskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -253,13 +268,20 @@ func (r *TaskResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
 	r.client = providerData.Client
 	r.org = providerData.Org
+	r.url = providerData.URL
+	r.orgs = providerData.Orgs
+	r.unconfigured = providerData.Unconfigured
+	r.readOnly = providerData.ReadOnly
+	r.ignoreNormalization = providerData.IgnoreNormalization
+	r.api = providerData.API
+	r.batch = providerData.Batch
 }
 
 // validateScheduling ensures either 'every' or 'cron' is specified, but not both
@@ -335,29 +357,43 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Validate scheduling
-	if !r.validateScheduling(&data, &resp.Diagnostics) {
+	createTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_task", "Create", "")
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Use provider org if not specified
-	orgName := r.org
-	if !data.Org.IsNull() {
-		orgName = data.Org.ValueString()
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "create", "influxdb_task") {
+		return
 	}
 
-	// Resolve organization name to ID
-	orgsAPI := r.client.OrganizationsAPI()
-	org, err := orgsAPI.FindOrganizationByName(ctx, orgName)
+	// Validate scheduling
+	if !r.validateScheduling(&data, &resp.Diagnostics) {
+		return
+	}
+
+	// Resolve organization name to ID, using the provider org if not specified
+	orgID, err := resolveOrgID(ctx, r.orgs, data.OrgID, data.Org, r.org)
 	if err != nil {
-		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to find organization '%s', got error: %s", orgName, err))
+		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to resolve organization, got error: %s", err))
 		return
 	}
 
 	// Prepare task
 	task := &domain.Task{
 		Name:  data.Name.ValueString(),
-		OrgID: *org.Id,
+		OrgID: orgID,
 		Flux:  r.stripOptionTaskLine(data.Flux.ValueString()),
 	}
 
@@ -367,11 +403,7 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 		task.Description = &desc
 	}
 
-	// Set status (default to active)
-	status := domain.TaskStatusTypeActive
-	if !data.Status.IsNull() {
-		status = domain.TaskStatusType(data.Status.ValueString())
-	}
+	status := domain.TaskStatusType(data.Status.ValueString())
 	task.Status = &status
 
 	// Set scheduling
@@ -392,12 +424,20 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 	tasksAPI := r.client.TasksAPI()
 	createdTask, err := tasksAPI.CreateTask(ctx, task)
 	if err != nil {
-		resp.Diagnostics.AddError("Create - Client Error", fmt.Sprintf("Unable to create task, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Create - Client Error", fmt.Sprintf("Unable to create task, got error: %s", err), err, taskErrorHints)
 		return
 	}
 
 	// Save data into Terraform state
-	data.Org = types.StringValue(orgName) // Keep the original organization name/identifier that was used in config
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		// Report the provider default org, or the ID when the task was created by org_id
+		if r.org != "" && data.OrgID.IsUnknown() {
+			data.Org = types.StringValue(r.org)
+		} else {
+			data.Org = types.StringValue(orgID)
+		}
+	}
+	data.OrgID = types.StringValue(orgID)
 	r.setComputedFields(&data, createdTask)
 
 	// Ensure updated_at is never null - if InfluxDB doesn't provide it, use created_at
@@ -405,6 +445,7 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 		data.UpdatedAt = data.CreatedAt
 	}
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	setDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(setDiags...)
 }
@@ -419,19 +460,50 @@ func (r *TaskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get task by ID
-	tasksAPI := r.client.TasksAPI()
-	task, err := tasksAPI.GetTaskByID(ctx, data.ID.ValueString())
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_task", "Read", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get task by ID, refreshes are served from one listing of the org's tasks
+	body, err := r.batch.Get(ctx, "tasks", data.OrgID.ValueString(), data.ID.ValueString())
+	if removeIfNotFound(ctx, err, resp) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Read - Client Error", fmt.Sprintf("Unable to read task, got error: %s", err))
 		return
 	}
 
+	task := &domain.Task{}
+	if err := json.Unmarshal(body, task); err != nil {
+		resp.Diagnostics.AddError("Read - Parse Error", fmt.Sprintf("Unable to parse task response: %s", err))
+		return
+	}
+
 	// Preserve stable computed fields from existing state (these should never change after creation)
 	// Keep ID, CreatedAt, Org, UpdatedAt exactly as they are to prevent unnecessary drift
 	// UpdatedAt should only change when we actually modify the task, not on reads
 	// (data.ID, data.CreatedAt, data.Org, data.UpdatedAt already have correct values from req.State.Get)
 
+	// The org is only unknown after import
+	data.OrgID = types.StringValue(task.OrgID)
+	if data.Org.IsNull() || data.Org.IsUnknown() {
+		data.Org = types.StringValue(orgNameOrID(ctx, r.orgs, task.OrgID))
+	}
+
 	// Update fields that can actually change externally
 	data.Name = types.StringValue(task.Name)
 
@@ -469,7 +541,22 @@ func (r *TaskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Note: We don't update UpdatedAt in Read method - preserve existing state value
-	// This prevents unnecessary drift when InfluxDB hasn't actually updated the timestamp	// Always set state - let Terraform framework handle change detection
+	// This prevents unnecessary drift when InfluxDB hasn't actually updated the timestamp.
+	// Timestamps are only null after import.
+	if data.CreatedAt.IsNull() && task.CreatedAt != nil {
+		data.CreatedAt = formatTimestamp(task.CreatedAt)
+	}
+	if data.UpdatedAt.IsNull() && task.UpdatedAt != nil {
+		data.UpdatedAt = formatTimestamp(task.UpdatedAt)
+	}
+
+	// skip_destroy only exists in Terraform, e.g. an import starts without it
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
+	}
+
+	// Always set state - let Terraform framework handle change detection
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -484,6 +571,27 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, timeoutDiags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_task", "Update", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "update", "influxdb_task") {
+		return
+	}
+
 	// Read current state data (to get the ID and other computed fields)
 	stateDiags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(stateDiags...)
@@ -494,7 +602,12 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Use stable computed fields from state (these are not in plan but should be preserved)
 	data.ID = state.ID
 	data.CreatedAt = state.CreatedAt
-	data.Org = state.Org // Preserve org from state to prevent inconsistent result
+	if data.Org.IsUnknown() {
+		data.Org = state.Org
+	}
+	if data.OrgID.IsUnknown() {
+		data.OrgID = state.OrgID
+	}
 
 	// Validate scheduling
 	if !r.validateScheduling(&data, &resp.Diagnostics) {
@@ -581,7 +694,7 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	updatedTask, err := tasksAPI.UpdateTask(ctx, task)
 	if err != nil {
-		resp.Diagnostics.AddError("Update - Client Error", fmt.Sprintf("Unable to update task, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Update - Client Error", fmt.Sprintf("Unable to update task, got error: %s", err), err, taskErrorHints)
 		return
 	}
 
@@ -593,6 +706,7 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.UpdatedAt = types.StringValue(updatedTask.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"))
 	}
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, r.url, data.ID)...)
 	updateSetDiags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(updateSetDiags...)
 }
@@ -607,18 +721,77 @@ func (r *TaskResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	// The task is kept in InfluxDB, Terraform drops it from the state
+	if data.SkipDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "influxdb_task", "Delete", data.ID.ValueString())
+	defer tracing.End(span, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, r.readOnly, "delete", "influxdb_task") {
+		return
+	}
+
 	// Delete task
 	tasksAPI := r.client.TasksAPI()
 	task := &domain.Task{Id: data.ID.ValueString()}
 	err := tasksAPI.DeleteTask(ctx, task)
-	if err != nil {
+	if err != nil && !apiclient.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete task, got error: %s", err))
 		return
 	}
 }
 
 func (r *TaskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import using task ID
-	diags := resp.State.SetAttribute(ctx, path.Root("id"), req.ID)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Import using task ID or org/name
+	importByIDOrName(ctx, req, resp, r.orgs, r.org, r.url, "task", func(ctx context.Context, orgID, name string) ([]string, error) {
+		tasks, err := r.client.APIClient().GetTasks(ctx, &domain.GetTasksParams{
+			OrgID: &orgID,
+			Name:  &name,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var ids []string
+		if tasks.Tasks != nil {
+			for _, task := range *tasks.Tasks {
+				ids = append(ids, task.Id)
+			}
+		}
+		return ids, nil
+	})
+}
+
+func (r *TaskResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listConfigSchema()
+}
+
+func (r *TaskResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.unconfigured.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(r.unconfigured)
+		return
+	}
+
+	listCollection(ctx, req, stream, r, r.api, r.orgs, r.org, r.url, "tasks", nil)
 }
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+// taskRunPollInterval is the delay between status checks while waiting for a run
+const taskRunPollInterval = 2 * time.Second
+
+// taskRunWaitTimeout bounds how long an invocation waits for a run to finish
+const taskRunWaitTimeout = 10 * time.Minute
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.ActionWithConfigure = &TaskRunAction{}
+
+func NewTaskRunAction() action.Action {
+	return &TaskRunAction{}
+}
+
+// TaskRunAction starts a task run outside of its schedule
+type TaskRunAction struct {
+	client       influxdb2.Client
+	readOnly     bool
+	unconfigured diag.Diagnostics
+}
+
+// TaskRunActionModel describes the action data model.
+type TaskRunActionModel struct {
+	TaskID types.String `tfsdk:"task_id"`
+	Wait   types.Bool   `tfsdk:"wait"`
+}
+
+func (a *TaskRunAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_run"
+}
+
+func (a *TaskRunAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs an InfluxDB task once, overriding its schedule, e.g. to backfill right after changing its Flux script. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"task_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the task to run",
+			},
+			"wait": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Wait for the run to finish and fail when it does not succeed. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (a *TaskRunAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = providerData.Client
+	a.readOnly = providerData.ReadOnly
+	a.unconfigured = providerData.Unconfigured
+}
+
+func (a *TaskRunAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	resp.Diagnostics.Append(a.unconfigured...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data TaskRunActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !common.CheckWritable(&resp.Diagnostics, a.readOnly, "invoke", "influxdb_task_run") {
+		return
+	}
+
+	tasksAPI := a.client.TasksAPI()
+	run, err := tasksAPI.RunManuallyWithID(ctx, data.TaskID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invoke - Client Error", fmt.Sprintf("Unable to run task %s, got error: %s", data.TaskID.ValueString(), err))
+		return
+	}
+	if run.Id == nil {
+		resp.Diagnostics.AddError("Invoke - Client Error", fmt.Sprintf("Unable to run task %s, the response has no run ID", data.TaskID.ValueString()))
+		return
+	}
+	runID := *run.Id
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Started run %s of task %s", runID, data.TaskID.ValueString())})
+	if !data.Wait.ValueBool() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, taskRunWaitTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Invoke - Timeout", fmt.Sprintf("Run %s of task %s did not finish: %s", runID, data.TaskID.ValueString(), ctx.Err()))
+			return
+		case <-time.After(taskRunPollInterval):
+		}
+
+		run, err = tasksAPI.GetRunByID(ctx, data.TaskID.ValueString(), runID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invoke - Client Error", fmt.Sprintf("Unable to read run of task %s, got error: %s", data.TaskID.ValueString(), err))
+			return
+		}
+
+		if run.Status == nil {
+			continue
+		}
+
+		switch *run.Status {
+		case domain.RunStatusSuccess:
+			resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Run %s of task %s succeeded", runID, data.TaskID.ValueString())})
+			return
+		case domain.RunStatusFailed, domain.RunStatusCanceled:
+			resp.Diagnostics.AddError("Invoke - Task Run Failed", fmt.Sprintf("Run %s of task %s finished with status %q%s", runID, data.TaskID.ValueString(), *run.Status, runLogMessages(run)))
+			return
+		}
+	}
+}
+
+// runLogMessages joins the log messages of a finished run for error details
+func runLogMessages(run *domain.Run) string {
+	if run.Log == nil {
+		return ""
+	}
+
+	var messages string
+	for _, event := range *run.Log {
+		if event.Message != nil {
+			messages += "\n" + *event.Message
+		}
+	}
+	return messages
+}
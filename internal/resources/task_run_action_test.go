@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTaskRunActionWithoutRunID(t *testing.T) {
+	api := newMockAPI(t)
+	api.respond(http.MethodPost, "tasks/0000000000000001/runs", http.StatusCreated, `{"status":"scheduled"}`)
+
+	ctx := context.Background()
+	a := NewTaskRunAction().(*TaskRunAction)
+	configureResp := &action.ConfigureResponse{}
+	a.Configure(ctx, action.ConfigureRequest{ProviderData: api.providerData(0)}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure failed: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"task_id": tftypes.NewValue(tftypes.String, "0000000000000001"),
+			"wait":    tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}
+
+	resp := &action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+	a.Invoke(ctx, action.InvokeRequest{Config: config}, resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "no run ID") {
+		t.Fatalf("got diagnostics %v, want a missing run ID error", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,7 @@
+package resources
+
+import "time"
+
+// defaultTimeout limits each operation of a resource unless configured otherwise
+// in its timeouts block
+const defaultTimeout = 5 * time.Minute
@@ -0,0 +1,108 @@
+// Package sweep deletes resources leaked by interrupted acceptance test runs. Only
+// resources whose name starts with a test prefix are deleted.
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/xing/terraform-provider-influxdb/internal/apiclient"
+)
+
+// collection describes an API collection to sweep. Collections are swept in order,
+// so notification rules go before the endpoints they reference.
+type collection struct {
+	// name is the API path, which doubles as the key of the list in the response body
+	name string
+	// nameField is the field matched against the prefix, authorizations have no name
+	nameField string
+	// global collections are not scoped to the organization
+	global bool
+}
+
+var collections = []collection{
+	{name: "notificationRules", nameField: "name"},
+	{name: "notificationEndpoints", nameField: "name"},
+	{name: "checks", nameField: "name"},
+	{name: "tasks", nameField: "name"},
+	{name: "buckets", nameField: "name"},
+	{name: "authorizations", nameField: "description"},
+	// Organizations created by tests, deleting one deletes everything in it
+	{name: "orgs", nameField: "name", global: true},
+}
+
+// Result reports a deleted or failed resource
+type Result struct {
+	Collection string
+	ID         string
+	Name       string
+	Err        error
+}
+
+// Sweep deletes all buckets, tasks, checks, notification endpoints, notification
+// rules and authorizations of the organization whose name starts with prefix, and
+// all other organizations whose name starts with prefix
+func Sweep(ctx context.Context, api *apiclient.Client, orgID, prefix string) ([]Result, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to sweep without a name prefix")
+	}
+
+	var results []Result
+	for _, c := range collections {
+		// Collect all members before deleting, deletions would shift offset pages
+		members, err := list(ctx, api, c, orgID)
+		if err != nil {
+			return results, fmt.Errorf("unable to list %s: %w", c.name, err)
+		}
+
+		for _, member := range members {
+			if !strings.HasPrefix(member.name, prefix) || member.id == orgID {
+				continue
+			}
+
+			_, err := api.Do(ctx, http.MethodDelete, c.name+"/"+url.PathEscape(member.id), nil)
+			if apiclient.IsNotFound(err) {
+				err = nil
+			}
+			results = append(results, Result{Collection: c.name, ID: member.id, Name: member.name, Err: err})
+		}
+	}
+
+	return results, nil
+}
+
+type member struct {
+	id   string
+	name string
+}
+
+// list returns all members of a collection in the organization
+func list(ctx context.Context, api *apiclient.Client, c collection, orgID string) ([]member, error) {
+	query := url.Values{"orgID": {orgID}}
+	if c.global {
+		query = nil
+	}
+
+	items, err := api.ListAll(ctx, c.name, query)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]member, 0, len(items))
+	for _, item := range items {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(item, &fields); err != nil {
+			return nil, fmt.Errorf("unable to parse response: %w", err)
+		}
+
+		id, _ := fields["id"].(string)
+		name, _ := fields[c.nameField].(string)
+		members = append(members, member{id: id, name: name})
+	}
+
+	return members, nil
+}
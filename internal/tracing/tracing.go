@@ -0,0 +1,95 @@
+// Package tracing emits optional OpenTelemetry spans for resource operations and
+// API calls. Tracing is enabled by setting OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, spans are then exported via OTLP/HTTP.
+// Without either, all spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans of the provider
+const instrumentationName = "github.com/xing/terraform-provider-influxdb"
+
+var setupOnce sync.Once
+
+// Enabled reports whether an OTLP endpoint is configured
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs the OTLP exporter once per provider process when tracing is
+// enabled. Terraform stops providers without notice, so spans are exported as
+// soon as they end instead of in batches.
+func Setup(ctx context.Context, version string) error {
+	if !Enabled() {
+		return nil
+	}
+
+	var setupErr error
+	setupOnce.Do(func() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			setupErr = err
+			return
+		}
+
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(
+			sdktrace.WithSyncer(exporter),
+			sdktrace.WithResource(resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceName("terraform-provider-influxdb"),
+				semconv.ServiceVersion(version),
+			)),
+		))
+	})
+
+	return setupErr
+}
+
+// Transport wraps an HTTP transport so every API call gets a span carrying the
+// method, URL and status code
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// Start starts the span of a resource operation, e.g. Create of influxdb_bucket.
+// The ID is empty when it is not known yet.
+func Start(ctx context.Context, resourceType, operation, id string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("influxdb.resource.type", resourceType),
+		attribute.String("influxdb.operation", operation),
+	}
+	if id != "" {
+		attributes = append(attributes, attribute.String("influxdb.resource.id", id))
+	}
+
+	return otel.Tracer(instrumentationName).Start(ctx, resourceType+"."+operation, trace.WithAttributes(attributes...))
+}
+
+// End ends the span of a resource operation, marking it as failed when the
+// operation reported errors
+func End(span trace.Span, diags *diag.Diagnostics) {
+	if diags.HasError() {
+		for _, d := range diags.Errors() {
+			span.RecordError(errors.New(d.Summary() + ": " + d.Detail()))
+		}
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	span.End()
+}
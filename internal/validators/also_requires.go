@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// alsoRequiresOneOfValidator ensures at least one of the given attributes is
+// configured whenever the validated attribute is
+type alsoRequiresOneOfValidator struct {
+	expressions path.Expressions
+}
+
+func (v alsoRequiresOneOfValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v alsoRequiresOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("at least one of %s must also be configured", v.expressions)
+}
+
+func (v alsoRequiresOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	for _, expression := range v.expressions {
+		matchedPaths, diags := req.Config.PathMatches(ctx, req.PathExpression.Merge(expression))
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		for _, matchedPath := range matchedPaths {
+			var value attr.Value
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, matchedPath, &value)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Unknown values may still be set once they are known
+			if value.IsUnknown() || !value.IsNull() {
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Missing Attribute Configuration",
+		fmt.Sprintf("At least one of %s must be configured when %s is set.", v.expressions, req.Path),
+	)
+}
+
+// AlsoRequiresOneOf returns a validator which ensures at least one of the given
+// attributes is configured when the string attribute is set
+func AlsoRequiresOneOf(expressions ...path.Expression) validator.String {
+	return alsoRequiresOneOfValidator{
+		expressions: expressions,
+	}
+}
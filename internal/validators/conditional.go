@@ -0,0 +1,112 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requiredWhenValidator ensures attributes are configured when another attribute
+// has a given value. With atLeastOne set, configuring any of them is sufficient.
+type requiredWhenValidator struct {
+	condition  path.Path
+	value      string
+	required   []path.Path
+	atLeastOne bool
+}
+
+func (v requiredWhenValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v requiredWhenValidator) MarkdownDescription(ctx context.Context) string {
+	if v.atLeastOne {
+		return fmt.Sprintf("at least one of %s must be configured when %s is %q", joinPaths(v.required), v.condition, v.value)
+	}
+	return fmt.Sprintf("%s must be configured when %s is %q", joinPaths(v.required), v.condition, v.value)
+}
+
+func (v requiredWhenValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var condition types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.condition, &condition)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if condition.IsNull() || condition.IsUnknown() || condition.ValueString() != v.value {
+		return
+	}
+
+	var missing []path.Path
+	for _, required := range v.required {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, required, &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Unknown values may still be set once they are known
+		if value.IsUnknown() {
+			return
+		}
+		if value.IsNull() {
+			missing = append(missing, required)
+		}
+	}
+
+	if len(missing) == 0 || (v.atLeastOne && len(missing) < len(v.required)) {
+		return
+	}
+
+	if v.atLeastOne {
+		resp.Diagnostics.AddAttributeError(
+			v.condition,
+			"Missing Required Attribute",
+			fmt.Sprintf("At least one of %s must be configured when %s is %q.", joinPaths(v.required), v.condition, v.value),
+		)
+		return
+	}
+
+	for _, attribute := range missing {
+		resp.Diagnostics.AddAttributeError(
+			attribute,
+			"Missing Required Attribute",
+			fmt.Sprintf("The attribute %s must be configured when %s is %q.", attribute, v.condition, v.value),
+		)
+	}
+}
+
+// joinPaths formats paths as a comma separated list
+func joinPaths(paths []path.Path) string {
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// RequiredWhen returns a resource validator which ensures all required attributes
+// are configured when the condition attribute equals value
+func RequiredWhen(condition path.Path, value string, required ...path.Path) resource.ConfigValidator {
+	return requiredWhenValidator{
+		condition: condition,
+		value:     value,
+		required:  required,
+	}
+}
+
+// AtLeastOneOfWhen returns a resource validator which ensures at least one of the
+// attributes is configured when the condition attribute equals value
+func AtLeastOneOfWhen(condition path.Path, value string, attributes ...path.Path) resource.ConfigValidator {
+	return requiredWhenValidator{
+		condition:  condition,
+		value:      value,
+		required:   attributes,
+		atLeastOne: true,
+	}
+}
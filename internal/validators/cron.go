@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cronFieldPattern matches a single field of a cron expression, e.g. "*/5" or "MON-FRI"
+var cronFieldPattern = regexp.MustCompile(`^[0-9A-Za-z*/,\-?#]+$`)
+
+// cronDescriptors are the predefined schedules InfluxDB accepts instead of fields
+var cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// cronValidator validates that a string attribute is a cron expression
+type cronValidator struct{}
+
+func (v cronValidator) Description(ctx context.Context) string {
+	return "value must be a cron expression such as 0 */1 * * * or @daily"
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a cron expression such as `0 */1 * * *` or `@daily`"
+}
+
+func (v cronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateCron(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Expression", err.Error())
+	}
+}
+
+// validateCron checks the shape of a cron expression. InfluxDB accepts five fields,
+// optionally preceded by seconds and followed by years.
+func validateCron(value string) error {
+	for _, descriptor := range cronDescriptors {
+		if value == descriptor {
+			return nil
+		}
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) < 5 || len(fields) > 7 {
+		return fmt.Errorf("%q is not a valid cron expression, expected 5 to 7 fields like 0 */1 * * * or a descriptor like @daily", value)
+	}
+
+	for _, field := range fields {
+		if !cronFieldPattern.MatchString(field) {
+			return fmt.Errorf("%q is not a valid cron expression, field %q contains invalid characters", value, field)
+		}
+	}
+
+	return nil
+}
+
+// Cron returns a validator which ensures the value is a cron expression
+func Cron() validator.String {
+	return cronValidator{}
+}
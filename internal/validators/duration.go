@@ -0,0 +1,116 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// durationPartPattern matches a single magnitude/unit pair of an InfluxDB duration literal
+var durationPartPattern = regexp.MustCompile(`(\d+)(ns|us|µs|ms|mo|s|m|h|d|w|y)`)
+
+// durationUnits maps InfluxDB duration units to their length. Months and years
+// have no fixed length, so they are approximated as 30 and 365 days.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// ParseDuration parses an InfluxDB duration literal such as "1h30m" or "0s"
+func ParseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, fmt.Errorf("duration must not be empty")
+	}
+
+	var total time.Duration
+	consumed := 0
+	for _, match := range durationPartPattern.FindAllStringSubmatchIndex(value, -1) {
+		if match[0] != consumed {
+			break
+		}
+		magnitude, err := strconv.ParseInt(value[match[2]:match[3]], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid magnitude in duration %q: %w", value, err)
+		}
+		total += time.Duration(magnitude) * durationUnits[value[match[4]:match[5]]]
+		consumed = match[1]
+	}
+
+	if consumed != len(value) {
+		return 0, fmt.Errorf("%q is not a valid duration, expected values like 30s, 5m or 1h30m", value)
+	}
+
+	return total, nil
+}
+
+// durationValidator validates that a string attribute is an InfluxDB duration literal
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be an InfluxDB duration such as 30s, 5m or 1h30m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an InfluxDB duration such as `30s`, `5m` or `1h30m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", err.Error())
+	}
+}
+
+// Duration returns a validator which ensures the value is an InfluxDB duration literal
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+// formatUnits are the units used by FormatDuration, largest first. Weeks, months
+// and years are left out so the result is exact and easy to read.
+var formatUnits = []struct {
+	name   string
+	length time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// FormatDuration formats a duration as the shortest InfluxDB duration literal,
+// e.g. 90 seconds as "1m30s"
+func FormatDuration(duration time.Duration) string {
+	if duration == 0 {
+		return "0s"
+	}
+
+	var formatted string
+	for _, unit := range formatUnits {
+		if magnitude := duration / unit.length; magnitude > 0 {
+			formatted += strconv.FormatInt(int64(magnitude), 10) + unit.name
+			duration -= magnitude * unit.length
+		}
+	}
+
+	return formatted
+}
@@ -0,0 +1,115 @@
+package validators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "0s", want: 0},
+		{value: "30s", want: 30 * time.Second},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "90s", want: 90 * time.Second},
+		{value: "1d", want: 24 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "1mo", want: 30 * 24 * time.Hour},
+		{value: "1y", want: 365 * 24 * time.Hour},
+		{value: "1m1mo", want: time.Minute + 30*24*time.Hour},
+		{value: "10ms", want: 10 * time.Millisecond},
+		{value: "5us", want: 5 * time.Microsecond},
+		{value: "5µs", want: 5 * time.Microsecond},
+		{value: "7ns", want: 7 * time.Nanosecond},
+		{value: "", wantErr: true},
+		{value: "30", wantErr: true},
+		{value: "s", wantErr: true},
+		{value: "1.5h", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "1h ", wantErr: true},
+		{value: " 1h", wantErr: true},
+		{value: "1x", wantErr: true},
+		{value: "99999999999999999999s", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseDuration(test.value)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDuration(%q) = %s, want error", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) failed: %s", test.value, err)
+			}
+			if got != test.want {
+				t.Errorf("ParseDuration(%q) = %s, want %s", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "0s"},
+		{duration: 90 * time.Second, want: "1m30s"},
+		{duration: time.Hour, want: "1h"},
+		{duration: 36 * time.Hour, want: "1d12h"},
+		{duration: 7 * 24 * time.Hour, want: "7d"},
+		{duration: 1500 * time.Millisecond, want: "1s500ms"},
+		{duration: time.Microsecond + time.Nanosecond, want: "1us1ns"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := FormatDuration(test.duration); got != test.want {
+				t.Errorf("FormatDuration(%s) = %q, want %q", test.duration, got, test.want)
+			}
+
+			// Formatted durations are valid literals of the same length
+			parsed, err := ParseDuration(test.want)
+			if err != nil || parsed != test.duration {
+				t.Errorf("ParseDuration(%q) = %s, %v, want %s", test.want, parsed, err, test.duration)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "valid", value: types.StringValue("5m")},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "invalid", value: types.StringValue("5 minutes"), wantErr: true},
+		{name: "empty", value: types.StringValue(""), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("every"), ConfigValue: test.value}
+			resp := &validator.StringResponse{}
+			Duration().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("got diagnostics %v, want error: %t", resp.Diagnostics, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Status returns a validator which ensures the value is a resource status,
+// active or inactive
+func Status() validator.String {
+	return stringvalidator.OneOf(
+		string(domain.TaskStatusTypeActive),
+		string(domain.TaskStatusTypeInactive),
+	)
+}
+
+// CheckLevel returns a validator which ensures the value is a level a check can
+// report
+func CheckLevel() validator.String {
+	return stringvalidator.OneOf(
+		string(domain.CheckStatusLevelOK),
+		string(domain.CheckStatusLevelINFO),
+		string(domain.CheckStatusLevelWARN),
+		string(domain.CheckStatusLevelCRIT),
+	)
+}
+
+// RuleLevel returns a validator which ensures the value is a level a status rule
+// can match
+func RuleLevel() validator.String {
+	return stringvalidator.OneOf(
+		string(domain.RuleStatusLevelOK),
+		string(domain.RuleStatusLevelINFO),
+		string(domain.RuleStatusLevelWARN),
+		string(domain.RuleStatusLevelCRIT),
+		string(domain.RuleStatusLevelUNKNOWN),
+		string(domain.RuleStatusLevelANY),
+	)
+}
@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// offsetBeforeEveryValidator ensures a schedule offset is shorter than the schedule interval
+type offsetBeforeEveryValidator struct {
+	every  path.Path
+	offset path.Path
+}
+
+func (v offsetBeforeEveryValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s must be shorter than %s", v.offset, v.every)
+}
+
+func (v offsetBeforeEveryValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("`%s` must be shorter than `%s`", v.offset, v.every)
+}
+
+func (v offsetBeforeEveryValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var every, offset types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.every, &every)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.offset, &offset)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if every.IsNull() || every.IsUnknown() || offset.IsNull() || offset.IsUnknown() {
+		return
+	}
+
+	// Malformed values are reported by the attribute level Duration validator
+	everyDuration, err := ParseDuration(every.ValueString())
+	if err != nil {
+		return
+	}
+	offsetDuration, err := ParseDuration(offset.ValueString())
+	if err != nil {
+		return
+	}
+
+	if offsetDuration >= everyDuration {
+		resp.Diagnostics.AddAttributeError(
+			v.offset,
+			"Invalid Offset",
+			fmt.Sprintf("The offset %q must be shorter than the schedule interval %q.", offset.ValueString(), every.ValueString()),
+		)
+	}
+}
+
+// OffsetBeforeEvery returns a resource validator which ensures the offset attribute
+// is shorter than the every attribute when both are configured
+func OffsetBeforeEvery(every, offset path.Path) resource.ConfigValidator {
+	return offsetBeforeEveryValidator{
+		every:  every,
+		offset: offset,
+	}
+}
@@ -0,0 +1,59 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOffsetBeforeEvery(t *testing.T) {
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"every":  schema.StringAttribute{Optional: true},
+			"offset": schema.StringAttribute{Optional: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"every":  tftypes.String,
+		"offset": tftypes.String,
+	}}
+
+	tests := []struct {
+		name    string
+		every   tftypes.Value
+		offset  tftypes.Value
+		wantErr bool
+	}{
+		{name: "shorter", every: tftypes.NewValue(tftypes.String, "1h"), offset: tftypes.NewValue(tftypes.String, "5m")},
+		{name: "different units", every: tftypes.NewValue(tftypes.String, "1d"), offset: tftypes.NewValue(tftypes.String, "23h59m")},
+		{name: "equal", every: tftypes.NewValue(tftypes.String, "1h"), offset: tftypes.NewValue(tftypes.String, "60m"), wantErr: true},
+		{name: "longer", every: tftypes.NewValue(tftypes.String, "5m"), offset: tftypes.NewValue(tftypes.String, "10m"), wantErr: true},
+		{name: "without offset", every: tftypes.NewValue(tftypes.String, "5m"), offset: tftypes.NewValue(tftypes.String, nil)},
+		{name: "unknown every", every: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), offset: tftypes.NewValue(tftypes.String, "10m")},
+		// Malformed values are reported by the Duration validator instead
+		{name: "malformed", every: tftypes.NewValue(tftypes.String, "often"), offset: tftypes.NewValue(tftypes.String, "10m")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{
+				Schema: configSchema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"every":  test.every,
+					"offset": test.offset,
+				}),
+			}}
+			resp := &resource.ValidateConfigResponse{}
+			OffsetBeforeEvery(path.Root("every"), path.Root("offset")).ValidateResource(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("got diagnostics %v, want error: %t", resp.Diagnostics, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Package vcr records the InfluxDB API calls of the provider to a cassette file and
+// replays them without a server, so behavior against a specific InfluxDB version
+// can be captured once and reproduced deterministically. It is enabled by setting
+// INFLUXDB_VCR_MODE to "record" or "replay" and INFLUXDB_VCR_CASSETTE to the
+// cassette path. Secrets are scrubbed before anything is written.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/xing/terraform-provider-influxdb/internal/common"
+)
+
+const (
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response. Requests are stored
+// without host and headers, so a cassette replays against any server URL.
+type Interaction struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Query       string `json:"query,omitempty"`
+	RequestBody string `json:"request_body,omitempty"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// FromEnv wraps base according to INFLUXDB_VCR_MODE and INFLUXDB_VCR_CASSETTE.
+// Without a mode base is returned unchanged.
+func FromEnv(base http.RoundTripper) (http.RoundTripper, error) {
+	mode := os.Getenv("INFLUXDB_VCR_MODE")
+	if mode == "" {
+		return base, nil
+	}
+
+	path := os.Getenv("INFLUXDB_VCR_CASSETTE")
+	if path == "" {
+		return nil, fmt.Errorf("INFLUXDB_VCR_CASSETTE must be set when INFLUXDB_VCR_MODE is %q", mode)
+	}
+
+	return New(mode, path, base)
+}
+
+// New returns a transport recording the calls sent through base to the cassette at
+// path, or replaying them from it without calling base. Recording appends to an
+// existing cassette, as Terraform starts a new provider process for every command.
+func New(mode, path string, base http.RoundTripper) (http.RoundTripper, error) {
+	if mode != ModeRecord && mode != ModeReplay {
+		return nil, fmt.Errorf("unknown VCR mode %q, expected %q or %q", mode, ModeRecord, ModeReplay)
+	}
+
+	cassette, err := load(path)
+	if err != nil {
+		if mode == ModeReplay || !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to read cassette %s: %w", path, err)
+		}
+	}
+
+	return &recorder{
+		mode:     mode,
+		path:     path,
+		base:     base,
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+type recorder struct {
+	mode string
+	path string
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	interaction := Interaction{
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Query:       req.URL.Query().Encode(),
+		RequestBody: scrub(requestBody),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, interaction)
+	}
+	return r.record(req, interaction)
+}
+
+func (r *recorder) record(req *http.Request, interaction Interaction) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction.Status = resp.StatusCode
+	interaction.ContentType = resp.Header.Get("Content-Type")
+	interaction.Body = scrub(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+
+	// Terraform stops providers without notice, so the cassette is saved after
+	// every call instead of on shutdown
+	if err := save(r.path, r.cassette); err != nil {
+		return nil, fmt.Errorf("unable to write cassette %s: %w", r.path, err)
+	}
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request. Once all
+// matching interactions were served, the last one is repeated, so repeated reads
+// replay the latest recorded state.
+func (r *recorder) replay(req *http.Request, interaction Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, recorded := range r.cassette.Interactions {
+		if !recorded.matches(interaction) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s in cassette %s", interaction.Method, interaction.Path, r.path)
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match]
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (i Interaction) matches(other Interaction) bool {
+	return i.Method == other.Method && i.Path == other.Path && i.Query == other.Query && i.RequestBody == other.RequestBody
+}
+
+// scrub redacts secrets in JSON bodies. JSON is re-encoded with sorted keys, so
+// request bodies match regardless of field order. Other bodies, such as Flux
+// queries, are kept as they are.
+func scrub(body []byte) string {
+	if redacted, ok := common.RedactJSON(body); ok {
+		return redacted
+	}
+	return string(body)
+}
+
+func load(path string) (Cassette, error) {
+	var cassette Cassette
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cassette, err
+	}
+	if err := json.Unmarshal(content, &cassette); err != nil {
+		return cassette, err
+	}
+	return cassette, nil
+}
+
+func save(path string, cassette Cassette) error {
+	content, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
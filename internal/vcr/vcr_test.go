@@ -0,0 +1,149 @@
+package vcr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func send(t *testing.T, transport http.RoundTripper, method, url, body string) (int, string) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, url, reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(respBody)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1","token":"created-secret"}`))
+		case r.Method == http.MethodGet:
+			reads++
+			if reads == 1 {
+				_, _ = w.Write([]byte(`{"id":"1","name":"first"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"id":"1","name":"second"}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	recorder, err := New(ModeRecord, cassette, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send(t, recorder, http.MethodPost, server.URL+"/api/v2/authorizations", `{"token":"request-secret","orgID":"o"}`)
+	send(t, recorder, http.MethodGet, server.URL+"/api/v2/authorizations/1", "")
+	send(t, recorder, http.MethodGet, server.URL+"/api/v2/authorizations/1", "")
+
+	content, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "secret") {
+		t.Errorf("cassette contains secrets: %s", content)
+	}
+
+	// Replaying needs no server, the URL of the recording does not matter
+	server.Close()
+	replayer, err := New(ModeReplay, cassette, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Request bodies match regardless of field order
+	status, body := send(t, replayer, http.MethodPost, "http://replay/api/v2/authorizations", `{"orgID":"o","token":"other-secret"}`)
+	if status != http.StatusCreated || body != `{"id":"1","token":"REDACTED"}` {
+		t.Errorf("got %d %s, want the recorded creation", status, body)
+	}
+
+	// Matching interactions are served in order, then the last one repeats
+	for _, want := range []string{"first", "second", "second"} {
+		if _, body := send(t, replayer, http.MethodGet, "http://replay/api/v2/authorizations/1", ""); !strings.Contains(body, want) {
+			t.Errorf("got %s, want name %q", body, want)
+		}
+	}
+
+	if _, err := replayer.RoundTrip(httptest.NewRequest(http.MethodDelete, "http://replay/api/v2/authorizations/1", nil)); err == nil {
+		t.Error("got no error for an unrecorded request")
+	}
+}
+
+func TestRecordAppends(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	// Terraform starts a provider process per command, each records to the cassette
+	for _, path := range []string{"/plan", "/apply"} {
+		recorder, err := New(ModeRecord, cassette, http.DefaultTransport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		send(t, recorder, http.MethodGet, server.URL+path, "")
+	}
+
+	loaded, err := load(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Interactions) != 2 {
+		t.Errorf("got %d interactions, want 2", len(loaded.Interactions))
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	base := http.DefaultTransport
+
+	t.Setenv("INFLUXDB_VCR_MODE", "")
+	if transport, err := FromEnv(base); err != nil || transport != base {
+		t.Errorf("got %v, %v, want the base transport without a mode", transport, err)
+	}
+
+	t.Setenv("INFLUXDB_VCR_MODE", ModeReplay)
+	t.Setenv("INFLUXDB_VCR_CASSETTE", "")
+	if _, err := FromEnv(base); err == nil {
+		t.Error("got no error without a cassette")
+	}
+
+	t.Setenv("INFLUXDB_VCR_CASSETTE", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := FromEnv(base); err == nil {
+		t.Error("got no error replaying a missing cassette")
+	}
+
+	t.Setenv("INFLUXDB_VCR_MODE", "rewind")
+	if _, err := FromEnv(base); err == nil {
+		t.Error("got no error for an unknown mode")
+	}
+}
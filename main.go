@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/xing/terraform-provider-influxdb/internal/metrics"
 	"github.com/xing/terraform-provider-influxdb/internal/provider"
 )
 
@@ -41,6 +42,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Serve returns once Terraform is done with the provider
+	if metricsErr := metrics.Finish(); metricsErr != nil {
+		log.Printf("[WARN] Unable to write InfluxDB API metrics: %s", metricsErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}